@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// how the days between two dates, and the fraction of a year they make, are counted
+type DayCount int
+
+const (
+	// every month has 30 days and the year 360, with the US rule for the 31st, a.k.a. bond basis
+	Thirty360 DayCount = iota
+	// actual days over a fixed 365 day year
+	Actual365
+	// actual days over a 360 day year, as used by money markets
+	Actual360
+)
+
+func (dc DayCount) String() string {
+	switch dc {
+	case Actual365:
+		return "ACT/365"
+	case Actual360:
+		return "ACT/360"
+	default:
+		return "30/360"
+	}
+}
+
+// returns the days from from to to, negative when to is before from
+// only the calendar dates are used, the time of day and location are ignored
+func (dc DayCount) Days(from time.Time, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+
+	if dc != Thirty360 {
+		a := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+		b := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+		return int(b.Sub(a).Hours() / 24)
+	}
+
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return 360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1)
+}
+
+// returns the fraction of a year from from to to
+func (dc DayCount) YearFraction(from time.Time, to time.Time) decimal.Decimal {
+	year := int64(360)
+	if dc == Actual365 {
+		year = 365
+	}
+
+	return decimal.NewFromInt(int64(dc.Days(from, to))).DivRound(decimal.NewFromInt(year), precision)
+}
+
+// returns t moved by n months, the day being clamped to the end of a shorter month, e.g. 31 Jan + 1 month is 28 Feb
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if d > last {
+		d = last
+	}
+
+	return time.Date(first.Year(), first.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
@@ -0,0 +1,215 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+func TestDays(t *testing.T) {
+	cases := []struct {
+		dc       DayCount
+		from, to time.Time
+		days     int
+	}{
+		{Thirty360, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), 180},
+		{Thirty360, time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), 31},
+		{Thirty360, time.Date(2022, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), 60},
+		{Thirty360, time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), 76},
+		{Actual365, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), 181},
+		{Actual360, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 29},
+		{Actual365, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), -59},
+	}
+
+	for _, c := range cases {
+		if d := c.dc.Days(c.from, c.to); d != c.days {
+			t.Fatalf("%s %s to %s: expected %d days, got %d", c.dc, c.from.Format("2006-01-02"), c.to.Format("2006-01-02"), c.days, d)
+		}
+	}
+
+	if f := Actual360.YearFraction(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)); !f.Equal(decimal.RequireFromString("0.1666666666666666666666667").Round(precision)) {
+		t.Fatalf("unexpected year fraction %s", f)
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	cases := []struct {
+		in       time.Time
+		n        int
+		expected time.Time
+	}{
+		{time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), 3, time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 11, 15, 0, 0, 0, 0, time.UTC), 3, time.Date(2023, 2, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		if r := addMonths(c.in, c.n); !r.Equal(c.expected) {
+			t.Fatalf("%s + %d months: expected %s, got %s", c.in.Format("2006-01-02"), c.n, c.expected.Format("2006-01-02"), r.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestSimpleInterest(t *testing.T) {
+	cases := []struct {
+		dc       DayCount
+		expected money.Money
+	}{
+		{Thirty360, money.NewEuro(25, 0)},
+		{Actual365, money.NewEuro(2479, -2)},
+		{Actual360, money.NewEuro(2514, -2)},
+	}
+
+	for _, c := range cases {
+		i, err := SimpleInterest(money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(500), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), c.dc, money.RoundHalfUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !i.Equal(c.expected) {
+			t.Fatalf("%s: expected %s, got %s", c.dc, c.expected, i)
+		}
+	}
+
+	i, err := SimpleInterest(money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(500), time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Actual365, money.RoundHalfUp)
+	if !errors.Is(err, ErrInvalidTerms) || i.IsValid() {
+		t.Fatalf("expected ErrInvalidTerms and invalid money, got %s, %v", i, err)
+	}
+}
+
+func TestCompoundInterest(t *testing.T) {
+	i, err := CompoundInterest(money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(500), 12, 12, money.RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !i.Equal(money.NewEuro(5116, -2)) {
+		t.Fatalf("expected 51.16 EUR compounded monthly, got %s", i)
+	}
+
+	i, err = CompoundInterest(money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(500), 1, 10, money.RoundDown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !i.Equal(money.NewEuro(62889, -2)) {
+		t.Fatalf("expected 628.89 EUR compounded yearly, got %s", i)
+	}
+
+	i, err = CompoundInterest(money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(500), 0, 10, money.RoundHalfUp)
+	if !errors.Is(err, ErrInvalidTerms) || i.IsValid() {
+		t.Fatalf("expected ErrInvalidTerms and invalid money, got %s, %v", i, err)
+	}
+	if _, err := CompoundInterest(money.New(1, 0, "ZZZ", "MAJOR"), money.NewPercentFromBasisPoints(500), 1, 1, money.RoundHalfUp); !errors.Is(err, money.ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+}
+
+func TestAnnuityPayment(t *testing.T) {
+	cases := []struct {
+		principal money.Money
+		rate      money.Percent
+		periods   int
+		expected  money.Money
+	}{
+		{money.New(200000, 0, "USD", "MAJOR"), money.NewPercentFromBasisPoints(650), 360, money.New(126414, -2, "USD", "MAJOR")},
+		{money.NewEuro(10000, 0), money.NewPercentFromBasisPoints(500), 12, money.NewEuro(85607, -2)},
+		{money.NewEuro(1000, 0), money.NewPercentFromBasisPoints(0), 3, money.NewEuro(33333, -2)},
+		{money.New(100000, 0, "JPY", "MAJOR"), money.NewPercentFromBasisPoints(1200), 12, money.New(8885, 0, "JPY", "MAJOR")},
+	}
+
+	for _, c := range cases {
+		p, err := AnnuityPayment(c.principal, c.rate, 12, c.periods, money.RoundHalfUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(c.expected) {
+			t.Fatalf("%s over %d periods: expected %s, got %s", c.principal, c.periods, c.expected, p)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	l := Loan{
+		Principal: money.NewEuro(10000, 0),
+		Rate:      money.NewPercentFromBasisPoints(500),
+		Periods:   12,
+		PerYear:   12,
+		Start:     time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	s, err := l.Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Instalments) != 12 {
+		t.Fatalf("expected 12 instalments, got %d", len(s.Instalments))
+	}
+
+	first := s.Instalments[0]
+	if !first.Payment.Equal(money.NewEuro(85607, -2)) || !first.Interest.Equal(money.NewEuro(4167, -2)) ||
+		!first.Principal.Equal(money.NewEuro(81440, -2)) || !first.Balance.Equal(money.NewEuro(918560, -2)) {
+		t.Fatalf("expected a first payment of 856.07 EUR, 41.67 EUR interest, 814.40 EUR principal and 9185.60 EUR left, got %s, %s, %s and %s",
+			first.Payment, first.Interest, first.Principal, first.Balance)
+	}
+	if !first.Due.Equal(time.Date(2022, 2, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected due date %s", first.Due)
+	}
+
+	assertReconciles(t, l, s)
+	last := s.Instalments[11]
+	if !last.Payment.Equal(money.NewEuro(85612, -2)) {
+		t.Fatalf("expected a last payment of 856.12 EUR, got %s", last.Payment)
+	}
+
+	for _, dc := range []DayCount{Actual365, Actual360} {
+		l.DayCount = dc
+		s, err := l.Schedule()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertReconciles(t, l, s)
+	}
+}
+
+// checks the principal repaid is the loan, and the payments are the principal plus the interest
+func assertReconciles(t *testing.T, l Loan, s Schedule) {
+	t.Helper()
+	principal, interest := money.NewEuro(0, 0), money.NewEuro(0, 0)
+	for n, i := range s.Instalments {
+		if sum, _ := i.Principal.AddErr(i.Interest); !sum.Equal(i.Payment) {
+			t.Fatalf("instalment %d: %s principal and %s interest do not add up to %s", n, i.Principal, i.Interest, i.Payment)
+		}
+		principal, _ = principal.AddErr(i.Principal)
+		interest, _ = interest.AddErr(i.Interest)
+	}
+
+	if !principal.Equal(l.Principal) {
+		t.Fatalf("expected %s principal repaid, got %s", l.Principal, principal)
+	}
+	if !interest.Equal(s.TotalInterest) {
+		t.Fatalf("expected %s total interest, got %s", interest, s.TotalInterest)
+	}
+	if b := s.Instalments[len(s.Instalments)-1].Balance; !b.IsZero() {
+		t.Fatalf("expected nothing left after the last instalment, got %s", b)
+	}
+	if total, _ := principal.AddErr(interest); !total.Equal(s.TotalPayment) {
+		t.Fatalf("expected %s total payment, got %s", total, s.TotalPayment)
+	}
+}
+
+func TestScheduleErrors(t *testing.T) {
+	for _, l := range []Loan{
+		{Principal: money.NewEuro(1000, 0), Periods: 0, PerYear: 12},
+		{Principal: money.NewEuro(1000, 0), Periods: 12, PerYear: 5},
+		{Principal: money.NewEuro(1000, 0), Periods: 12, PerYear: 0},
+	} {
+		if _, err := l.Schedule(); !errors.Is(err, ErrInvalidTerms) {
+			t.Fatalf("expected ErrInvalidTerms, got %v", err)
+		}
+		if p, err := l.Payment(); err == nil || p.IsValid() {
+			t.Fatalf("expected invalid money with the error, got %s", p)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+// the functions here return money.Invalid alongside an error, as the money package does
+var ErrInvalidTerms = errors.New("invalid terms")
+
+// decimal places kept in intermediate results, well beyond any currency's minor unit
+const precision = 24
+
+var one = decimal.NewFromInt(1)
+
+// returns d^n for n >= 0, rounding to precision as it goes so the digits do not grow with n
+func pow(d decimal.Decimal, n int) decimal.Decimal {
+	r := one
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = r.Mul(d).Round(precision)
+		}
+		d = d.Mul(d).Round(precision)
+	}
+
+	return r
+}
+
+// returns d as money in the major unit of the currency of m, rounded to its minor unit
+func major(d decimal.Decimal, m money.Money, mode money.RoundingMode) money.Money {
+	return money.NewFromDecimal(d, m.Currency(), "MAJOR").Round(mode)
+}
+
+func check(op string, m money.Money) error {
+	if !m.IsValid() {
+		return fmt.Errorf("finance: %s: %w", op, money.ErrInvalidMoney)
+	}
+
+	return nil
+}
+
+// returns the simple interest on principal at an annual rate from from to to
+func SimpleInterest(principal money.Money, rate money.Percent, from time.Time, to time.Time, dc DayCount, mode money.RoundingMode) (money.Money, error) {
+	if err := check("simple interest", principal); err != nil {
+		return money.Invalid(), err
+	}
+	if to.Before(from) {
+		return money.Invalid(), fmt.Errorf("finance: simple interest from %s to %s: %w", from.Format("2006-01-02"), to.Format("2006-01-02"), ErrInvalidTerms)
+	}
+
+	p := principal.ToMajor().ValueDecimal()
+	i := p.Mul(rate.Fraction()).Mul(dc.YearFraction(from, to))
+
+	return major(i, principal, mode), nil
+}
+
+// returns the interest on principal at an annual rate compounded perYear times a year, over periods periods
+// e.g. 12 and 24 for two years compounded monthly
+func CompoundInterest(principal money.Money, rate money.Percent, perYear int, periods int, mode money.RoundingMode) (money.Money, error) {
+	if err := check("compound interest", principal); err != nil {
+		return money.Invalid(), err
+	}
+	if perYear <= 0 || periods < 0 {
+		return money.Invalid(), fmt.Errorf("finance: compound interest %d times a year over %d periods: %w", perYear, periods, ErrInvalidTerms)
+	}
+
+	p := principal.ToMajor().ValueDecimal()
+	r := rate.Fraction().DivRound(decimal.NewFromInt(int64(perYear)), precision)
+	i := p.Mul(pow(one.Add(r), periods).Sub(one))
+
+	return major(i, principal, mode), nil
+}
+
+// returns the level payment paying off principal with interest over periods payments,
+// at an annual rate split evenly over perYear periods
+func AnnuityPayment(principal money.Money, rate money.Percent, perYear int, periods int, mode money.RoundingMode) (money.Money, error) {
+	if err := check("annuity payment", principal); err != nil {
+		return money.Invalid(), err
+	}
+	if perYear <= 0 || periods <= 0 {
+		return money.Invalid(), fmt.Errorf("finance: annuity payment %d times a year over %d periods: %w", perYear, periods, ErrInvalidTerms)
+	}
+
+	return major(annuity(principal.ToMajor().ValueDecimal(), rate, perYear, periods), principal, mode), nil
+}
+
+// p * r / (1 - (1 + r)^-n), or p / n without interest
+func annuity(p decimal.Decimal, rate money.Percent, perYear int, periods int) decimal.Decimal {
+	n := decimal.NewFromInt(int64(periods))
+	if rate.IsZero() {
+		return p.DivRound(n, precision)
+	}
+
+	r := rate.Fraction().DivRound(decimal.NewFromInt(int64(perYear)), precision)
+	f := pow(one.Add(r), periods)
+
+	return p.Mul(r).Mul(f).DivRound(f.Sub(one), precision)
+}
@@ -0,0 +1,120 @@
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+// a loan repaid in level instalments, i.e. an annuity
+type Loan struct {
+	Principal money.Money
+	// the annual nominal rate
+	Rate money.Percent
+	// the number of instalments
+	Periods int
+	// instalments a year, one of 1, 2, 3, 4, 6 or 12
+	PerYear int
+	// the instalments fall due every 12 / PerYear months from Start
+	Start time.Time
+	// the interest of each period is the balance * Rate * the fraction of a year between due dates
+	// with 30/360 and a Start before the 29th every period is exactly 1 / PerYear of a year
+	DayCount DayCount
+	Rounding money.RoundingMode
+}
+
+// one instalment of a schedule, Payment == Principal + Interest
+type Instalment struct {
+	Period    int
+	Due       time.Time
+	Payment   money.Money
+	Principal money.Money
+	Interest  money.Money
+	// the principal left after the payment
+	Balance money.Money
+}
+
+type Schedule struct {
+	Instalments []Instalment
+	// the sum of the payments, which is the principal plus TotalInterest
+	TotalPayment  money.Money
+	TotalInterest money.Money
+}
+
+func (l Loan) check() error {
+	if err := check("loan", l.Principal); err != nil {
+		return err
+	}
+	if l.Periods <= 0 || l.PerYear <= 0 || 12%l.PerYear != 0 {
+		return fmt.Errorf("finance: loan of %d periods %d times a year: %w", l.Periods, l.PerYear, ErrInvalidTerms)
+	}
+
+	return nil
+}
+
+// returns the level instalment, rounded to the minor unit
+func (l Loan) Payment() (money.Money, error) {
+	if err := l.check(); err != nil {
+		return money.Invalid(), err
+	}
+
+	return AnnuityPayment(l.Principal, l.Rate, l.PerYear, l.Periods, l.Rounding)
+}
+
+// splits every instalment into principal and interest, each rounded to the minor unit
+// the final payment is adjusted to clear the balance, so the principal repaid is exactly the loan
+func (l Loan) Schedule() (Schedule, error) {
+	payment, err := l.Payment()
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	code := l.Principal.Currency()
+	zero := money.NewFromDecimal(decimal.Zero, code, "MAJOR")
+	balance := l.Principal.ToMajor()
+	s := Schedule{TotalPayment: zero, TotalInterest: zero}
+	months := 12 / l.PerYear
+
+	due := l.Start
+	for k := 1; k <= l.Periods; k++ {
+		prev := due
+		due = addMonths(l.Start, k*months)
+
+		rate := l.Rate.Fraction().Mul(l.DayCount.YearFraction(prev, due))
+		interest := major(balance.ValueDecimal().Mul(rate), l.Principal, l.Rounding)
+
+		p := payment
+		if k == l.Periods {
+			if p, err = balance.AddErr(interest); err != nil {
+				return Schedule{}, err
+			}
+		}
+
+		principal, err := p.SubtractErr(interest)
+		if err != nil {
+			return Schedule{}, err
+		}
+		if balance, err = balance.SubtractErr(principal); err != nil {
+			return Schedule{}, err
+		}
+
+		s.Instalments = append(s.Instalments, Instalment{
+			Period:    k,
+			Due:       due,
+			Payment:   p,
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+		if s.TotalPayment, err = s.TotalPayment.AddErr(p); err != nil {
+			return Schedule{}, err
+		}
+		if s.TotalInterest, err = s.TotalInterest.AddErr(interest); err != nil {
+			return Schedule{}, err
+		}
+	}
+
+	return s, nil
+}
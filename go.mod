@@ -1,5 +1,5 @@
 module github.com/jacobklenner/go-utils
 
-go 1.17
+go 1.21
 
-require github.com/shopspring/decimal v1.3.1 // indirect
+require github.com/shopspring/decimal v1.3.1
@@ -0,0 +1,400 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+)
+
+var (
+	ErrUnbalanced           = errors.New("postings do not balance")
+	ErrNoPostings           = errors.New("no postings")
+	ErrInvalidPosting       = errors.New("invalid posting")
+	ErrDuplicateTransaction = errors.New("duplicate transaction")
+)
+
+// which side of an account a posting goes to
+type Side int
+
+const (
+	Debit Side = iota
+	Credit
+)
+
+func (s Side) String() string {
+	if s == Credit {
+		return "credit"
+	}
+
+	return "debit"
+}
+
+// a single debit or credit of a positive amount against an account
+type Posting struct {
+	Account string
+	Side    Side
+	Amount  money.Money
+}
+
+// the amount as it moves the balance of the account, debits positive and credits negative
+func (p Posting) signed() money.Money {
+	m := p.Amount.ToMajor()
+	if p.Side == Credit {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// a set of postings committed together, which must balance in every currency they touch
+type Transaction struct {
+	// left empty the ledger numbers transactions in commit order, skipping numbers already used as an ID
+	ID          string
+	Date        time.Time
+	Description string
+	Postings    []Posting
+}
+
+// adds a debit of m against account
+func (t *Transaction) Debit(account string, m money.Money) *Transaction {
+	t.Postings = append(t.Postings, Posting{Account: account, Side: Debit, Amount: m})
+	return t
+}
+
+// adds a credit of m against account
+func (t *Transaction) Credit(account string, m money.Money) *Transaction {
+	t.Postings = append(t.Postings, Posting{Account: account, Side: Credit, Amount: m})
+	return t
+}
+
+// checks every posting and that debits equal credits per currency
+func (t Transaction) Validate() error {
+	if len(t.Postings) == 0 {
+		return fmt.Errorf("ledger: transaction %q: %w", t.ID, ErrNoPostings)
+	}
+
+	net := make(map[string]money.Money)
+	for i, p := range t.Postings {
+		if p.Account == "" {
+			return fmt.Errorf("ledger: transaction %q posting %d has no account: %w", t.ID, i, ErrInvalidPosting)
+		}
+		if p.Side != Debit && p.Side != Credit {
+			return fmt.Errorf("ledger: transaction %q posting %d has side %d: %w", t.ID, i, p.Side, ErrInvalidPosting)
+		}
+		if !p.Amount.IsPositive() {
+			return fmt.Errorf("ledger: transaction %q posting %d amount %s is not positive: %w", t.ID, i, describe(p.Amount), ErrInvalidPosting)
+		}
+
+		code := p.Amount.Currency()
+		sum, ok := net[code]
+		if !ok {
+			net[code] = p.signed()
+			continue
+		}
+
+		sum, err := sum.AddErr(p.signed())
+		if err != nil {
+			return fmt.Errorf("ledger: transaction %q posting %d: %w", t.ID, i, err)
+		}
+		net[code] = sum
+	}
+
+	for _, code := range currencies(net) {
+		if d := net[code]; !d.IsZero() {
+			return fmt.Errorf("ledger: transaction %q is off by %s: %w", t.ID, describe(d), ErrUnbalanced)
+		}
+	}
+
+	return nil
+}
+
+// a double entry ledger keeping a running balance per account and currency
+// transactions are persisted to its Store, a Ledger is safe for concurrent use
+type Ledger struct {
+	mu       sync.RWMutex
+	store    Store
+	count    int
+	ids      map[string]bool
+	balances map[string]map[string]money.Money
+}
+
+// opens a ledger over s, replaying the transactions already stored to rebuild the balances
+func New(s Store) (*Ledger, error) {
+	l := &Ledger{store: s, ids: make(map[string]bool), balances: make(map[string]map[string]money.Money)}
+
+	ts, err := s.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range ts {
+		after, err := l.balancesAfter(t)
+		if err != nil {
+			return nil, err
+		}
+		l.apply(t, after)
+	}
+
+	return l, nil
+}
+
+// validates and stores t, then updates the balances
+// nothing is stored or changed when t does not balance or cannot be stored
+func (l *Ledger) Commit(t Transaction) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.ID == "" {
+		t.ID = l.nextID()
+	}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	t.Postings = append([]Posting(nil), t.Postings...)
+	after, err := l.balancesAfter(t)
+	if err != nil {
+		return err
+	}
+	if err := l.store.Append(t); err != nil {
+		return err
+	}
+	l.apply(t, after)
+
+	return nil
+}
+
+// the first number after the count of transactions that no transaction has as its ID
+func (l *Ledger) nextID() string {
+	for n := l.count + 1; ; n++ {
+		if id := strconv.Itoa(n); !l.ids[id] {
+			return id
+		}
+	}
+}
+
+// the balances t leaves the accounts it posts to with, by account and currency
+// the ledger itself is left unchanged until apply
+func (l *Ledger) balancesAfter(t Transaction) (map[string]map[string]money.Money, error) {
+	after := make(map[string]map[string]money.Money)
+	for _, p := range t.Postings {
+		bs, ok := after[p.Account]
+		if !ok {
+			bs = make(map[string]money.Money)
+			after[p.Account] = bs
+		}
+
+		code := p.Amount.Currency()
+		b, ok := bs[code]
+		if !ok {
+			b, ok = l.balances[p.Account][code]
+		}
+		if !ok {
+			bs[code] = p.signed()
+			continue
+		}
+
+		b, err := b.AddErr(p.signed())
+		if err != nil {
+			return nil, fmt.Errorf("ledger: transaction %q: %w", t.ID, err)
+		}
+		bs[code] = b
+	}
+
+	return after, nil
+}
+
+// swaps in the balances computed by balancesAfter and records t
+func (l *Ledger) apply(t Transaction, after map[string]map[string]money.Money) {
+	for account, bs := range after {
+		if l.balances[account] == nil {
+			l.balances[account] = make(map[string]money.Money)
+		}
+		for code, b := range bs {
+			l.balances[account][code] = b
+		}
+	}
+	l.ids[t.ID] = true
+	l.count++
+}
+
+// returns the balance of account in the given currency, debits positive
+// zero when the account has no postings in it, the currency code is matched in any case
+func (l *Ledger) Balance(account string, currency string) money.Money {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	zero := money.New(0, 0, currency, "MAJOR")
+	if b, ok := l.balances[account][zero.Currency()]; ok {
+		return b
+	}
+
+	return zero
+}
+
+// returns the balance of account in every currency it has postings in, ordered by currency code
+func (l *Ledger) Balances(account string) []money.Money {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	bs := l.balances[account]
+	ms := make([]money.Money, 0, len(bs))
+	for _, code := range currencies(bs) {
+		ms = append(ms, bs[code])
+	}
+
+	return ms
+}
+
+// returns the accounts with postings, in order
+func (l *Ledger) Accounts() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.accounts()
+}
+
+func (l *Ledger) accounts() []string {
+	as := make([]string, 0, len(l.balances))
+	for a := range l.balances {
+		as = append(as, a)
+	}
+	sort.Strings(as)
+
+	return as
+}
+
+// a line of an account statement, with the balance after the posting
+type Entry struct {
+	Transaction string
+	Date        time.Time
+	Description string
+	Posting     Posting
+	Balance     money.Money
+}
+
+// returns the postings against account in the given currency ordered by date, each with the running balance
+// the currency code is matched in any case
+func (l *Ledger) Statement(account string, currency string) ([]Entry, error) {
+	ts, err := l.store.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(ts, func(i, j int) bool { return ts[i].Date.Before(ts[j].Date) })
+
+	var es []Entry
+	balance := money.New(0, 0, currency, "MAJOR")
+	for _, t := range ts {
+		for _, p := range t.Postings {
+			if p.Account != account || p.Amount.Currency() != balance.Currency() {
+				continue
+			}
+
+			balance, err = balance.AddErr(p.signed())
+			if err != nil {
+				return nil, err
+			}
+			es = append(es, Entry{
+				Transaction: t.ID,
+				Date:        t.Date,
+				Description: t.Description,
+				Posting:     p,
+				Balance:     balance,
+			})
+		}
+	}
+
+	return es, nil
+}
+
+// a line of a trial balance, a debit balance goes in the Debit column and a credit balance in the Credit column
+type Line struct {
+	Account string
+	Debit   money.Money
+	Credit  money.Money
+}
+
+type TrialBalance struct {
+	// a line per account and currency, ordered by account then currency
+	Lines []Line
+	// a line per currency summing the columns, with an empty Account
+	Totals []Line
+}
+
+// whether the debit and credit columns agree in every currency
+func (tb TrialBalance) Balanced() bool {
+	for _, t := range tb.Totals {
+		if c, err := t.Debit.Cmp(t.Credit); err != nil || c != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lists the balance of every account, which sum to zero per currency when the ledger is consistent
+func (l *Ledger) TrialBalance() (TrialBalance, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var tb TrialBalance
+	totals := make(map[string]Line)
+	var codes []string
+
+	for _, account := range l.accounts() {
+		bs := l.balances[account]
+		for _, code := range currencies(bs) {
+			b := bs[code]
+			zero := money.New(0, 0, code, "MAJOR")
+
+			line := Line{Account: account, Debit: zero, Credit: zero}
+			if b.IsNegative() {
+				line.Credit = b.Neg()
+			} else {
+				line.Debit = b
+			}
+			tb.Lines = append(tb.Lines, line)
+
+			total, ok := totals[code]
+			if !ok {
+				total = Line{Debit: zero, Credit: zero}
+				codes = append(codes, code)
+			}
+
+			var err error
+			if total.Debit, err = total.Debit.AddErr(line.Debit); err != nil {
+				return TrialBalance{}, err
+			}
+			if total.Credit, err = total.Credit.AddErr(line.Credit); err != nil {
+				return TrialBalance{}, err
+			}
+			totals[code] = total
+		}
+	}
+
+	sort.Strings(codes)
+	for _, code := range codes {
+		tb.Totals = append(tb.Totals, totals[code])
+	}
+
+	return tb, nil
+}
+
+// returns the currency codes of a set of balances, in order
+func currencies(bs map[string]money.Money) []string {
+	codes := make([]string, 0, len(bs))
+	for code := range bs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+func describe(m money.Money) string {
+	return m.ValueDecimal().String() + " " + m.Currency()
+}
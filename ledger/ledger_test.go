@@ -0,0 +1,224 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+)
+
+func TestValidate(t *testing.T) {
+	ten := money.NewEuro(10, 0)
+	cases := []struct {
+		name string
+		t    Transaction
+		err  error
+	}{
+		{"empty", Transaction{}, ErrNoPostings},
+		{"balanced", *(&Transaction{}).Debit("cash", ten).Credit("sales", ten), nil},
+		{"across units", *(&Transaction{}).Debit("cash", ten).Credit("sales", money.NewEuroCent(1000, 0)), nil},
+		{"split", *(&Transaction{}).Debit("cash", ten).Credit("sales", money.NewEuro(8, 0)).Credit("vat", money.NewEuro(2, 0)), nil},
+		{"unbalanced", *(&Transaction{}).Debit("cash", ten).Credit("sales", money.NewEuro(999, -2)), ErrUnbalanced},
+		{"per currency", *(&Transaction{}).Debit("cash", ten).Credit("sales", money.New(10, 0, "USD", "MAJOR")), ErrUnbalanced},
+		{"no account", *(&Transaction{}).Debit("", ten).Credit("sales", ten), ErrInvalidPosting},
+		{"negative", *(&Transaction{}).Debit("cash", ten.Neg()).Credit("sales", ten.Neg()), ErrInvalidPosting},
+		{"bad side", Transaction{Postings: []Posting{{Account: "cash", Side: 2, Amount: ten}}}, ErrInvalidPosting},
+	}
+
+	for _, c := range cases {
+		err := c.t.Validate()
+		if c.err == nil && err != nil {
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+		if c.err != nil && !errors.Is(err, c.err) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestCommit(t *testing.T) {
+	l, err := New(NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sale := (&Transaction{Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), Description: "sale"}).
+		Debit("cash", money.NewEuro(120, 0)).Credit("sales", money.NewEuro(100, 0)).Credit("vat", money.NewEuro(20, 0))
+	if err := l.Commit(*sale); err != nil {
+		t.Fatal(err)
+	}
+	refund := (&Transaction{Date: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), Description: "refund"}).
+		Debit("sales", money.NewEuro(25, 0)).Credit("cash", money.NewEuro(25, 0))
+	if err := l.Commit(*refund); err != nil {
+		t.Fatal(err)
+	}
+	fx := (&Transaction{ID: "fx-1", Date: time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)}).
+		Debit("cash", money.New(5, 0, "USD", "MAJOR")).Credit("fx", money.New(5, 0, "USD", "MAJOR"))
+	if err := l.Commit(*fx); err != nil {
+		t.Fatal(err)
+	}
+
+	bad := (&Transaction{}).Debit("cash", money.NewEuro(1, 0)).Credit("sales", money.NewEuro(5, -1))
+	if err := l.Commit(*bad); !errors.Is(err, ErrUnbalanced) {
+		t.Fatalf("expected ErrUnbalanced, got %v", err)
+	}
+	if err := l.Commit(*fx); !errors.Is(err, ErrDuplicateTransaction) {
+		t.Fatalf("expected ErrDuplicateTransaction, got %v", err)
+	}
+
+	balances := []struct {
+		account  string
+		currency string
+		e        money.Money
+	}{
+		{"cash", "EUR", money.NewEuro(95, 0)},
+		{"cash", "eur", money.NewEuro(95, 0)},
+		{"cash", "USD", money.New(5, 0, "USD", "MAJOR")},
+		{"sales", "EUR", money.NewEuro(-75, 0)},
+		{"rent", "EUR", money.NewEuro(0, 0)},
+	}
+	for _, c := range balances {
+		if r := l.Balance(c.account, c.currency); !r.Equal(c.e) {
+			t.Fatalf("%s in %s: expected %s, got %s", c.account, c.currency, c.e, r)
+		}
+	}
+
+	if bs := l.Balances("cash"); len(bs) != 2 || bs[0].Currency() != "EUR" || bs[1].Currency() != "USD" {
+		t.Fatalf("unexpected balances %v", bs)
+	}
+	if as := l.Accounts(); len(as) != 4 || as[0] != "cash" || as[3] != "vat" {
+		t.Fatalf("unexpected accounts %v", as)
+	}
+
+	for _, code := range []string{"EUR", "eur"} {
+		es, err := l.Statement("cash", code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(es) != 2 || es[0].Transaction != "1" || es[1].Transaction != "2" {
+			t.Fatalf("unexpected statement in %s %v", code, es)
+		}
+		if !es[0].Balance.Equal(money.NewEuro(120, 0)) || !es[1].Balance.Equal(money.NewEuro(95, 0)) {
+			t.Fatalf("expected balances of 120.00 EUR and 95.00 EUR, got %s and %s", es[0].Balance, es[1].Balance)
+		}
+	}
+
+	// a new ledger over the same store replays it
+	r, err := New(l.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := r.Balance("cash", "EUR"); !b.Equal(money.NewEuro(95, 0)) {
+		t.Fatalf("expected 95.00 EUR after replaying, got %s", b)
+	}
+}
+
+// a store refusing every transaction
+type failingStore struct {
+	MemoryStore
+}
+
+var errStore = errors.New("store unavailable")
+
+func (s *failingStore) Append(t Transaction) error {
+	return errStore
+}
+
+func TestCommitStoreFailure(t *testing.T) {
+	l, err := New(&failingStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sale := (&Transaction{}).Debit("cash", money.NewEuro(10, 0)).Credit("sales", money.NewEuro(10, 0))
+	if err := l.Commit(*sale); !errors.Is(err, errStore) {
+		t.Fatalf("expected the store error, got %v", err)
+	}
+
+	if as := l.Accounts(); len(as) != 0 {
+		t.Fatalf("expected no accounts after a failed commit, got %v", as)
+	}
+	if b := l.Balance("cash", "EUR"); !b.IsZero() {
+		t.Fatalf("expected a zero balance after a failed commit, got %s", b)
+	}
+
+	l.store = NewMemoryStore()
+	if err := l.Commit(*sale); err != nil {
+		t.Fatal(err)
+	}
+	es, err := l.Statement("cash", "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 || es[0].Transaction != "1" {
+		t.Fatalf("expected the failed commit not to use up an ID, got %v", es)
+	}
+}
+
+func TestCommitSkipsUsedIDs(t *testing.T) {
+	l, err := New(NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Commit(*(&Transaction{ID: "2"}).Debit("cash", money.NewEuro(1, 0)).Credit("sales", money.NewEuro(1, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Commit(*(&Transaction{}).Debit("cash", money.NewEuro(1, 0)).Credit("sales", money.NewEuro(1, 0))); err != nil {
+		t.Fatalf("did not expect a numbered transaction to clash, got %v", err)
+	}
+
+	es, err := l.Statement("cash", "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 2 || es[0].Transaction != "2" || es[1].Transaction != "3" {
+		t.Fatalf("unexpected statement %v", es)
+	}
+}
+
+func TestTrialBalance(t *testing.T) {
+	l, err := New(NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range []*Transaction{
+		(&Transaction{}).Debit("cash", money.NewEuro(120, 0)).Credit("sales", money.NewEuro(100, 0)).Credit("vat", money.NewEuro(20, 0)),
+		(&Transaction{}).Debit("rent", money.NewEuro(30, 0)).Credit("cash", money.NewEuro(30, 0)),
+		(&Transaction{}).Debit("cash", money.New(5, 0, "USD", "MAJOR")).Credit("fx", money.New(5, 0, "USD", "MAJOR")),
+	} {
+		if err := l.Commit(*tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tb, err := l.TrialBalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tb.Balanced() {
+		t.Fatalf("expected a balanced trial balance")
+	}
+
+	expected := []Line{
+		{"cash", money.NewEuro(90, 0), money.NewEuro(0, 0)},
+		{"cash", money.New(5, 0, "USD", "MAJOR"), money.New(0, 0, "USD", "MAJOR")},
+		{"fx", money.New(0, 0, "USD", "MAJOR"), money.New(5, 0, "USD", "MAJOR")},
+		{"rent", money.NewEuro(30, 0), money.NewEuro(0, 0)},
+		{"sales", money.NewEuro(0, 0), money.NewEuro(100, 0)},
+		{"vat", money.NewEuro(0, 0), money.NewEuro(20, 0)},
+		{"", money.NewEuro(120, 0), money.NewEuro(120, 0)},
+		{"", money.New(5, 0, "USD", "MAJOR"), money.New(5, 0, "USD", "MAJOR")},
+	}
+	lines := append(tb.Lines, tb.Totals...)
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines and totals, got %d", len(expected), len(lines))
+	}
+	for i, e := range expected {
+		r := lines[i]
+		if r.Account != e.Account || !r.Debit.Equal(e.Debit) || !r.Credit.Equal(e.Credit) {
+			t.Fatalf("line %d: expected %q %s / %s, got %q %s / %s", i, e.Account, e.Debit, e.Credit, r.Account, r.Debit, r.Credit)
+		}
+	}
+}
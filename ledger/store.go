@@ -0,0 +1,167 @@
+package ledger
+
+import (
+	stdsql "database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jacobklenner/go-utils/sql"
+)
+
+// where a ledger persists its transactions
+// transactions are only ever appended, and are returned in the order they were appended
+type Store interface {
+	// stores a validated transaction, ErrDuplicateTransaction when its ID is already stored
+	Append(t Transaction) error
+	Transactions() ([]Transaction, error)
+}
+
+// keeps transactions in memory, the zero value is ready to use
+type MemoryStore struct {
+	mu           sync.RWMutex
+	transactions []Transaction
+	ids          map[string]bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(t Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ids[t.ID] {
+		return fmt.Errorf("ledger: transaction %q: %w", t.ID, ErrDuplicateTransaction)
+	}
+	if s.ids == nil {
+		s.ids = make(map[string]bool)
+	}
+
+	t.Postings = append([]Posting(nil), t.Postings...)
+	s.transactions = append(s.transactions, t)
+	s.ids[t.ID] = true
+
+	return nil
+}
+
+func (s *MemoryStore) Transactions() ([]Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ts := make([]Transaction, len(s.transactions))
+	for i, t := range s.transactions {
+		t.Postings = append([]Posting(nil), t.Postings...)
+		ts[i] = t
+	}
+
+	return ts, nil
+}
+
+// the columns of the postings table used by SQLStore, one row per posting
+var postingColumns = []string{"transaction_id", "date", "description", "account", "side", "amount"}
+
+// keeps transactions in a postings table, e.g.
+//
+//	CREATE TABLE postings (
+//		id             INTEGER PRIMARY KEY AUTO_INCREMENT,
+//		transaction_id VARCHAR(64) NOT NULL,
+//		date           DATETIME NOT NULL,
+//		description    TEXT NOT NULL,
+//		account        VARCHAR(255) NOT NULL,
+//		side           VARCHAR(6) NOT NULL,
+//		amount         VARCHAR(64) NOT NULL,
+//		INDEX (transaction_id)
+//	);
+//
+// Query names the database and table, amounts are stored as money.SQLText
+// the id column keeps the commit order, an index on transaction_id keeps the duplicate check in Append cheap
+type SQLStore struct {
+	DB    *stdsql.DB
+	Query sql.Query
+}
+
+func (s *SQLStore) table() string {
+	return fmt.Sprintf("%s.%s", s.Query.Database, s.Query.Table)
+}
+
+// inserts the postings of t in one database transaction, after checking no rows have its ID
+// the check and the inserts share the database transaction, so concurrent writers need serializable isolation
+func (s *SQLStore) Append(t Transaction) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(postingColumns)), ", ")
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", s.table(), strings.Join(postingColumns, ", "), placeholders)
+
+	exists := s.Query
+	exists.SelectOne().Where(sql.Column{Name: "transaction_id"}.Equal("?")).Limit(1)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	var one int
+	switch err := tx.QueryRow(exists.Query, t.ID).Scan(&one); err {
+	case stdsql.ErrNoRows:
+	case nil:
+		tx.Rollback()
+		return fmt.Errorf("ledger: transaction %q: %w", t.ID, ErrDuplicateTransaction)
+	default:
+		tx.Rollback()
+		return fmt.Errorf("ledger: store transaction %q: %w", t.ID, err)
+	}
+
+	for _, p := range t.Postings {
+		if _, err := tx.Exec(insert, t.ID, t.Date, t.Description, p.Account, p.Side.String(), p.Amount); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("ledger: store transaction %q: %w", t.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// reads the postings table back in commit order, grouping the rows into transactions by ID
+// a transaction is placed by its first row
+func (s *SQLStore) Transactions() ([]Transaction, error) {
+	q := s.Query
+	q.Select(postingColumns).OrderByAsc(sql.Column{Name: "id"})
+
+	rows, err := s.DB.Query(q.Query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ts []Transaction
+	index := make(map[string]int)
+	for rows.Next() {
+		var (
+			t    Transaction
+			p    Posting
+			side string
+		)
+		if err := rows.Scan(&t.ID, &t.Date, &t.Description, &p.Account, &side, &p.Amount); err != nil {
+			return nil, err
+		}
+
+		switch side {
+		case "debit":
+			p.Side = Debit
+		case "credit":
+			p.Side = Credit
+		default:
+			return nil, fmt.Errorf("ledger: transaction %q has side %q: %w", t.ID, side, ErrInvalidPosting)
+		}
+
+		if i, ok := index[t.ID]; ok {
+			ts[i].Postings = append(ts[i].Postings, p)
+			continue
+		}
+		t.Postings = []Posting{p}
+		index[t.ID] = len(ts)
+		ts = append(ts, t)
+	}
+
+	return ts, rows.Err()
+}
@@ -0,0 +1,183 @@
+package ledger
+
+import (
+	stdsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/jacobklenner/go-utils/sql"
+)
+
+// a database/sql driver keeping the inserted rows of one table in memory
+type fakeDriver struct {
+	rows    [][]driver.Value
+	queries []string
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+type fakeConn struct {
+	d       *fakeDriver
+	pending [][]driver.Value
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.queries = append(c.d.queries, query)
+	return &fakeStmt{c: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *fakeConn) Commit() error {
+	c.d.rows = append(c.d.rows, c.pending...)
+	c.pending = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.pending = nil
+	return nil
+}
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.pending = append(s.c.pending, args)
+	return driver.RowsAffected(1), nil
+}
+
+// answers the duplicate check from Append with the rows having the ID, and anything else with every row
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.Contains(s.query, "WHERE transaction_id = ?") {
+		return &fakeRows{columns: postingColumns, rows: s.c.d.rows}, nil
+	}
+
+	var rows [][]driver.Value
+	for _, table := range [][][]driver.Value{s.c.d.rows, s.c.pending} {
+		for _, row := range table {
+			if row[0] == args[0] {
+				rows = append(rows, []driver.Value{int64(1)})
+			}
+		}
+	}
+	return &fakeRows{columns: []string{"1"}, rows: rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestSQLStore(t *testing.T) {
+	d := &fakeDriver{}
+	stdsql.Register("ledgertest", d)
+	db, err := stdsql.Open("ledgertest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	s := &SQLStore{DB: db, Query: sql.Query{Database: "books", Table: "postings"}}
+	l, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sale := (&Transaction{Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), Description: "sale"}).
+		Debit("cash", money.NewEuro(120, 0)).Credit("sales", money.NewEuro(100, 0)).Credit("vat", money.NewEuro(20, 0))
+	if err := l.Commit(*sale); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Commit(*(&Transaction{}).Debit("cash", money.New(5, 0, "USD", "MAJOR")).Credit("fx", money.New(5, 0, "USD", "MAJOR"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(d.rows))
+	}
+	if d.rows[0][5] != "120 EUR" || d.rows[1][4] != "credit" {
+		t.Fatalf("unexpected row %v", d.rows[0])
+	}
+	if err := s.Append(*(&Transaction{ID: "1"}).Debit("cash", money.NewEuro(1, -2)).Credit("sales", money.NewEuro(1, -2))); !errors.Is(err, ErrDuplicateTransaction) {
+		t.Fatalf("expected ErrDuplicateTransaction, got %v", err)
+	}
+	if len(d.rows) != 5 {
+		t.Fatalf("expected the duplicate to insert nothing, got %d rows", len(d.rows))
+	}
+
+	insert := "INSERT INTO books.postings (transaction_id, date, description, account, side, amount) VALUES (?, ?, ?, ?, ?, ?);"
+	if !contains(d.queries, insert) {
+		t.Fatalf("expected query %s in %v", insert, d.queries)
+	}
+
+	ts, err := s.Transactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ts) != 2 || len(ts[0].Postings) != 3 || ts[0].Description != "sale" || ts[1].ID != "2" {
+		t.Fatalf("unexpected transactions %v", ts)
+	}
+	sel := "SELECT transaction_id, date, description, account, side, amount FROM books.postings ORDER BY id ASC;"
+	if !contains(d.queries, sel) {
+		t.Fatalf("expected query %s in %v", sel, d.queries)
+	}
+
+	r, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := r.Balance("cash", "EUR"); !b.Equal(money.NewEuro(120, 0)) {
+		t.Fatalf("expected 120.00 EUR in cash, got %s", b)
+	}
+	if b := r.Balance("fx", "USD"); !b.Equal(money.New(-5, 0, "USD", "MAJOR")) {
+		t.Fatalf("expected -5.00 USD in fx, got %s", b)
+	}
+
+	// rows of a transaction need not be adjacent, e.g. when written by another client
+	d.rows[2], d.rows[3] = d.rows[3], d.rows[2]
+	ts, err = s.Transactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ts) != 2 || len(ts[0].Postings) != 3 || len(ts[1].Postings) != 2 {
+		t.Fatalf("unexpected transactions %v", ts)
+	}
+}
+
+func contains(qs []string, q string) bool {
+	for _, s := range qs {
+		if strings.TrimSpace(s) == q {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package money
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// money that can be totalled and compared by the generic helpers, i.e. Money or a currency typed
+// Amount from the typed package, Currency being empty for invalid money
+type Summable[T any] interface {
+	AddErr(T) (T, error)
+	Currency() string
+}
+
+type Ordered[T any] interface {
+	Cmp(T) (int, error)
+	Currency() string
+}
+
+// what the generic helpers return alongside an error, invalid money for Money
+func failed[T any]() T {
+	var r T
+	if m, ok := any(&r).(*Money); ok {
+		*m = defaultMoney()
+	}
+
+	return r
+}
+
+// returns the total of the money, which must all share a currency, in the unit of the first
+func Sum[T Summable[T]](ms []T) (T, error) {
+	if len(ms) == 0 {
+		return failed[T](), fmt.Errorf("money: sum: %w", ErrEmpty)
+	}
+	if ms[0].Currency() == "" {
+		return failed[T](), fmt.Errorf("money: sum: %w", ErrInvalidMoney)
+	}
+
+	r := ms[0]
+	for _, m := range ms[1:] {
+		var err error
+		if r, err = r.AddErr(m); err != nil {
+			return failed[T](), err
+		}
+	}
+
+	return r, nil
+}
+
+// returns the mean of the money, which must all share a currency, rounded to the minor unit with mode
+// RoundNone keeps the full precision of the division
+func Average(ms []Money, mode RoundingMode) (Money, error) {
+	s, err := Sum(ms)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	s = s.withDec(s.dec().Div(decimal.NewFromInt(int64(len(ms)))))
+
+	return s.Round(mode), nil
+}
+
+// returns the total of the money in the given currency, skipping the rest
+// zero in the major unit when there is none
+func SumBy(ms []Money, code string) (Money, error) {
+	c, ok := parseCurrency(code)
+	if !ok {
+		return defaultMoney(), fmt.Errorf("money: sum by %s: %w", code, ErrUnknownCurrency)
+	}
+
+	r := zero(c, MAJOR)
+	for _, m := range ms {
+		if m.currency != c {
+			continue
+		}
+
+		var err error
+		if r, err = r.AddErr(m); err != nil {
+			return defaultMoney(), err
+		}
+	}
+
+	return r, nil
+}
+
+// splits the money by currency code, keeping the order within each currency
+// each group can be totalled with Sum, or a Bag keeps a running total per currency
+func GroupByCurrency(ms []Money) (map[string][]Money, error) {
+	groups := make(map[string][]Money)
+	for _, m := range ms {
+		if !m.valid() {
+			return nil, fmt.Errorf("money: group by currency %s: %w", m.string(), ErrInvalidMoney)
+		}
+
+		code := m.currency.string()
+		groups[code] = append(groups[code], m)
+	}
+
+	return groups, nil
+}
+
+// a running total, count, min and max of a stream of money in one currency
+// the currency is fixed by NewAccumulator, or by the first money added to the zero value
+// it is safe to add from several goroutines
+type Accumulator struct {
+	mu    sync.Mutex
+	sum   Money
+	min   Money
+	max   Money
+	count int
+	err   error
+	// whether the currency of sum has been fixed
+	set bool
+}
+
+// returns an accumulator that only accepts money in the given currency
+func NewAccumulator(code string) (*Accumulator, error) {
+	c, ok := parseCurrency(code)
+	if !ok {
+		return nil, fmt.Errorf("money: accumulate %s: %w", code, ErrUnknownCurrency)
+	}
+
+	return &Accumulator{sum: zero(c, MAJOR), set: true}, nil
+}
+
+// adds m to the total, returning an error and leaving the total as it was when m is invalid or in another currency
+// the first such error is also kept, see Err
+func (a *Accumulator) Add(m Money) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.add(m); err != nil {
+		if a.err == nil {
+			a.err = err
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (a *Accumulator) add(m Money) error {
+	if !m.valid() {
+		return fmt.Errorf("money: accumulate %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	if !a.set {
+		a.sum = zero(m.currency, m.unit)
+		a.set = true
+	}
+
+	sum, err := a.sum.AddErr(m)
+	if err != nil {
+		return err
+	}
+
+	if a.count == 0 {
+		a.min, a.max = m, m
+	} else {
+		if c, _ := m.Cmp(a.min); c < 0 {
+			a.min = m
+		}
+		if c, _ := m.Cmp(a.max); c > 0 {
+			a.max = m
+		}
+	}
+
+	a.sum = sum
+	a.count++
+
+	return nil
+}
+
+// returns the first error from Add, nil when every money was accepted
+func (a *Accumulator) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.err
+}
+
+// returns the number of money added
+func (a *Accumulator) Count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.count
+}
+
+// returns the total, zero for an accumulator with a currency but nothing added
+func (a *Accumulator) Sum() (Money, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.set {
+		return defaultMoney(), fmt.Errorf("money: accumulator sum: %w", ErrEmpty)
+	}
+
+	return a.sum, nil
+}
+
+func (a *Accumulator) Min() (Money, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 {
+		return defaultMoney(), fmt.Errorf("money: accumulator min: %w", ErrEmpty)
+	}
+
+	return a.min, nil
+}
+
+func (a *Accumulator) Max() (Money, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 {
+		return defaultMoney(), fmt.Errorf("money: accumulator max: %w", ErrEmpty)
+	}
+
+	return a.max, nil
+}
+
+// returns the mean rounded to the minor unit with mode
+func (a *Accumulator) Average(mode RoundingMode) (Money, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 {
+		return defaultMoney(), fmt.Errorf("money: accumulator average: %w", ErrEmpty)
+	}
+
+	m := a.sum.withDec(a.sum.dec().Div(decimal.NewFromInt(int64(a.count))))
+
+	return m.Round(mode), nil
+}
@@ -0,0 +1,144 @@
+package money
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	s, err := Sum([]Money{NewEuro(1, 0), NewEuroCent(250, 0), NewEuro(-5, -1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(3, 0), s)
+
+	if _, err := Sum[Money](nil); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := Sum([]Money{NewEuro(1, 0), ZeroUsDollar()}); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := Sum([]Money{defaultMoney()}); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	ms := []Money{NewEuro(1, 0), NewEuro(1, 0), NewEuro(2, 0)}
+
+	a, err := Average(ms, RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(133, -2), a)
+
+	a, err = Average(ms, RoundUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(134, -2), a)
+
+	if _, err := Average(nil, RoundHalfUp); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestSumByAndGroup(t *testing.T) {
+	ms := []Money{NewEuro(1, 0), New(2, 0, "USD", "MAJOR"), NewEuroCent(50, 0), New(3, 0, "USD", "MAJOR")}
+
+	s, err := SumBy(ms, "eur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(15, -1), s)
+
+	s, err = SumBy(ms, "GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(New(0, 0, "GBP", "MAJOR"), s)
+
+	if _, err := SumBy(ms, "ZZZ"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency, got %v", err)
+	}
+
+	groups, err := GroupByCurrency(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || len(groups["EUR"]) != 2 || len(groups["USD"]) != 2 {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(50, 0), groups["EUR"][1])
+
+	if _, err := GroupByCurrency([]Money{defaultMoney()}); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	var a Accumulator
+	if _, err := a.Sum(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	for _, m := range []Money{NewEuro(2, 0), NewEuroCent(50, 0), NewEuro(5, 0)} {
+		if err := a.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.Add(ZeroUsDollar()); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if err := a.Add(defaultMoney()); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+	if !errors.Is(a.Err(), ErrCurrencyMismatch) {
+		t.Fatalf("expected the first error to be kept, got %v", a.Err())
+	}
+
+	s, _ := a.Sum()
+	moneyTest{t}.assertMoneyEqual(NewEuro(75, -1), s)
+	min, _ := a.Min()
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(50, 0), min)
+	max, _ := a.Max()
+	moneyTest{t}.assertMoneyEqual(NewEuro(5, 0), max)
+	avg, _ := a.Average(RoundHalfUp)
+	moneyTest{t}.assertMoneyEqual(NewEuro(25, -1), avg)
+	if a.Count() != 3 {
+		t.Fatalf("expected 3, got %d", a.Count())
+	}
+}
+
+func TestAccumulatorConcurrent(t *testing.T) {
+	a, err := NewAccumulator("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := a.Sum()
+	moneyTest{t}.assertMoneyEqual(ZeroEuro(), s)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				a.Add(NewEuroCent(1, 0))
+			}
+			a.Add(ZeroUsDollar())
+		}()
+	}
+	wg.Wait()
+
+	s, _ = a.Sum()
+	moneyTest{t}.assertMoneyEqual(NewEuro(80, 0), s)
+	if a.Count() != 8000 || a.Err() == nil {
+		t.Fatalf("expected 8000 added and an error, got %d and %v", a.Count(), a.Err())
+	}
+
+	if _, err := NewAccumulator("ZZZ"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidAllocation = errors.New("invalid allocation")
+
+// how the units left over after an allocation are handed out
+// allocation works in the currency's minor unit, or the money's own precision where that is finer,
+// so each share is first truncated to that unit and the leftover units are then distributed one or more at a time
+type Remainder int
+
+const (
+	// one unit each to the shares that lost the most to truncation, ties going to the earlier share
+	RemainderLargest Remainder = iota
+	// all of the leftover to the first share with a non zero ratio
+	RemainderFirst
+	// one unit each to the shares in order, starting from the first
+	RemainderRoundRobin
+)
+
+// splits the money into n equal shares that sum exactly to the original
+// e.g. 100 EUR / 3 = 33.34, 33.33, 33.33
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("money: split %s into %d: %w", m.string(), n, ErrInvalidAllocation)
+	}
+
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.AllocateWith(RemainderLargest, ratios...)
+}
+
+// allocates the money by the given ratios into shares that sum exactly to the original
+// the leftover is handed out with RemainderLargest
+// e.g. 0.05 EUR by 3:7 = 0.02, 0.03
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	return m.AllocateWith(RemainderLargest, ratios...)
+}
+
+// allocates the money by the given ratios, handing out the leftover with r
+func (m Money) AllocateWith(r Remainder, ratios ...int) ([]Money, error) {
+	if !m.valid() {
+		return nil, fmt.Errorf("money: allocate %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	sum := big.NewInt(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("money: allocate %s by %v: negative ratio: %w", m.string(), ratios, ErrInvalidAllocation)
+		}
+		sum.Add(sum, big.NewInt(int64(ratio)))
+	}
+
+	if sum.Sign() == 0 {
+		return nil, fmt.Errorf("money: allocate %s by %v: ratios sum to zero: %w", m.string(), ratios, ErrInvalidAllocation)
+	}
+
+	ci, _ := m.currency.info()
+	places := ci.exponent
+	if m.unit == MINOR {
+		places = 0
+	}
+	if v := m.dec(); -v.Exponent() > places {
+		places = -v.Exponent()
+	}
+
+	total := m.dec().Shift(places).BigInt()
+	negative := total.Sign() < 0
+	total.Abs(total)
+
+	shares := make([]*big.Int, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	left := big.NewInt(0).Set(total)
+
+	for i, ratio := range ratios {
+		shares[i], remainders[i] = big.NewInt(0).QuoRem(
+			big.NewInt(0).Mul(total, big.NewInt(int64(ratio))), sum, big.NewInt(0),
+		)
+		left.Sub(left, shares[i])
+	}
+
+	// leftover is less than the number of ratios, so it fits an int64
+	r.distribute(left.Int64(), ratios, shares, remainders)
+
+	ms := make([]Money, len(ratios))
+	for i, share := range shares {
+		if negative {
+			share.Neg(share)
+		}
+
+		ms[i] = Money{
+			value:    decimal.NewFromBigInt(share, -places),
+			currency: m.currency,
+			unit:     m.unit,
+		}.packed()
+	}
+
+	return ms, nil
+}
+
+func (r Remainder) distribute(left int64, ratios []int, shares []*big.Int, remainders []*big.Int) {
+	one := big.NewInt(1)
+
+	switch r {
+	case RemainderFirst:
+		for i, ratio := range ratios {
+			if ratio != 0 {
+				shares[i].Add(shares[i], big.NewInt(left))
+				return
+			}
+		}
+	case RemainderRoundRobin:
+		for i := 0; left > 0; i = (i + 1) % len(ratios) {
+			if ratios[i] != 0 {
+				shares[i].Add(shares[i], one)
+				left--
+			}
+		}
+	default:
+		order := make([]int, len(ratios))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.SliceStable(order, func(a, b int) bool {
+			return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+		})
+
+		for _, i := range order[:left] {
+			shares[i].Add(shares[i], one)
+		}
+	}
+}
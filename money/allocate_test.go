@@ -0,0 +1,137 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func assertAllocation(t *testing.T, m Money, expected []Money, shares []Money) {
+	if len(shares) != len(expected) {
+		t.Fatalf("expected %d shares but got %d", len(expected), len(shares))
+	}
+
+	total := Money{currency: m.currency, unit: m.unit}
+
+	for i, s := range shares {
+		moneyTest{t}.assertMoneyEqual(expected[i], s)
+		total, _ = total.Add(s)
+	}
+
+	if !total.Equal(m) {
+		t.Fatalf("expected shares to sum to %s but got %s", m.string(), total.string())
+	}
+}
+
+func TestSplit(t *testing.T) {
+	m := NewEuro(100, 0)
+
+	shares, err := m.Split(3)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, m, []Money{NewEuro(3334, -2), NewEuro(3333, -2), NewEuro(3333, -2)}, shares)
+}
+
+func TestSplitNegative(t *testing.T) {
+	m := NewEuro(-100, 0)
+
+	shares, err := m.Split(3)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, m, []Money{NewEuro(-3334, -2), NewEuro(-3333, -2), NewEuro(-3333, -2)}, shares)
+}
+
+func TestSplitMinorUnitAndZeroExponent(t *testing.T) {
+	cent := NewEuroCent(10, 0)
+
+	shares, err := cent.Split(4)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, cent, []Money{NewEuroCent(3, 0), NewEuroCent(3, 0), NewEuroCent(2, 0), NewEuroCent(2, 0)}, shares)
+
+	jpy := New(1000, 0, "JPY", "yen")
+
+	shares, err = jpy.Split(3)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, jpy, []Money{New(334, 0, "JPY", "yen"), New(333, 0, "JPY", "yen"), New(333, 0, "JPY", "yen")}, shares)
+}
+
+func TestSplitSubMinorPrecision(t *testing.T) {
+	m := NewEuro(1001, -3)
+
+	shares, err := m.Split(2)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, m, []Money{NewEuro(501, -3), NewEuro(500, -3)}, shares)
+}
+
+func TestAllocate(t *testing.T) {
+	m := NewEuro(5, -2)
+
+	shares, err := m.Allocate(3, 7)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	assertAllocation(t, m, []Money{NewEuro(2, -2), NewEuro(3, -2)}, shares)
+}
+
+func TestAllocateRemainderPolicies(t *testing.T) {
+	m := NewEuro(100, -2)
+
+	// 1.00 by 1:1:1:3 truncates to 0.16, 0.16, 0.16, 0.50 leaving 0.02
+	ratios := []int{1, 1, 1, 3}
+
+	shares, err := m.AllocateWith(RemainderLargest, ratios...)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	assertAllocation(t, m, []Money{NewEuro(17, -2), NewEuro(17, -2), NewEuro(16, -2), NewEuro(50, -2)}, shares)
+
+	shares, err = m.AllocateWith(RemainderFirst, ratios...)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	assertAllocation(t, m, []Money{NewEuro(18, -2), NewEuro(16, -2), NewEuro(16, -2), NewEuro(50, -2)}, shares)
+
+	// 1.00 by 0:2:1 truncates to 0, 0.66, 0.33 leaving 0.01, which skips the zero ratio
+	shares, err = m.AllocateWith(RemainderRoundRobin, 0, 2, 1)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	assertAllocation(t, m, []Money{NewEuro(0, -2), NewEuro(67, -2), NewEuro(33, -2)}, shares)
+}
+
+func TestAllocateErrors(t *testing.T) {
+	m := NewEuro(100, 0)
+
+	if _, err := m.Split(0); !errors.Is(err, ErrInvalidAllocation) {
+		t.Fatalf("expected ErrInvalidAllocation but got %v", err)
+	}
+
+	if _, err := m.Allocate(); !errors.Is(err, ErrInvalidAllocation) {
+		t.Fatalf("expected ErrInvalidAllocation but got %v", err)
+	}
+
+	if _, err := m.Allocate(1, -1); !errors.Is(err, ErrInvalidAllocation) {
+		t.Fatalf("expected ErrInvalidAllocation but got %v", err)
+	}
+
+	if _, err := m.Allocate(0, 0); !errors.Is(err, ErrInvalidAllocation) {
+		t.Fatalf("expected ErrInvalidAllocation but got %v", err)
+	}
+
+	if _, err := defaultMoney().Split(2); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+}
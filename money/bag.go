@@ -0,0 +1,186 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// a running balance per currency, e.g. a cart or an account holding several currencies
+// each balance keeps the unit of the first money added in its currency
+// the zero value is an empty bag, a Bag is not safe for concurrent use
+type Bag struct {
+	balances map[currency]Money
+}
+
+func NewBag(ms ...Money) (*Bag, error) {
+	b := &Bag{}
+	for _, m := range ms {
+		if err := b.Add(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// adds m to the balance of its currency
+func (b *Bag) Add(m Money) error {
+	return b.apply(m, Money.AddErr)
+}
+
+// subtracts m from the balance of its currency, which can go negative
+func (b *Bag) Subtract(m Money) error {
+	return b.apply(m, Money.SubtractErr)
+}
+
+func (b *Bag) apply(m Money, op func(Money, Money) (Money, error)) error {
+	if !m.valid() {
+		return fmt.Errorf("money: bag %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	if b.balances == nil {
+		b.balances = make(map[currency]Money)
+	}
+
+	balance, ok := b.balances[m.currency]
+	if !ok {
+		balance = zero(m.currency, m.unit)
+	}
+
+	r, err := op(balance, m)
+	if err != nil {
+		return err
+	}
+	b.balances[m.currency] = r
+
+	return nil
+}
+
+// returns the balance in the given currency, zero if nothing has been added in it
+// and invalid money for an unknown currency code
+func (b *Bag) Balance(code string) Money {
+	c, ok := parseCurrency(code)
+	if !ok {
+		return defaultMoney()
+	}
+
+	if m, ok := b.balances[c]; ok {
+		return m
+	}
+
+	return zero(c, MAJOR)
+}
+
+// returns every balance, ordered by currency code
+func (b *Bag) Balances() []Money {
+	ms := make([]Money, 0, len(b.balances))
+	for _, m := range b.balances {
+		ms = append(ms, m)
+	}
+	sort.Sort(ByAmount(ms))
+
+	return ms
+}
+
+// returns the codes of the currencies held, in order
+func (b *Bag) Currencies() []string {
+	ms := b.Balances()
+	codes := make([]string, len(ms))
+	for i, m := range ms {
+		codes[i] = m.Currency()
+	}
+
+	return codes
+}
+
+// whether every balance is zero
+func (b *Bag) IsZero() bool {
+	for _, m := range b.balances {
+		if !m.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// converts every balance to the target currency with c and sums them
+// a balance already in the target currency is added exactly, without a conversion
+// the conversions are returned alongside, in the order of Balances, so the total can be audited
+func (b *Bag) Total(c Converter, to string, on time.Time) (Money, []Conversion, error) {
+	target, ok := parseCurrency(to)
+	if !ok {
+		return defaultMoney(), nil, fmt.Errorf("money: bag total in %s: %w", to, ErrUnknownCurrency)
+	}
+
+	total := zero(target, MAJOR)
+	var conversions []Conversion
+
+	for _, m := range b.Balances() {
+		if m.currency == target {
+			var err error
+			if total, err = total.AddErr(m); err != nil {
+				return defaultMoney(), nil, err
+			}
+			continue
+		}
+
+		conv, err := c.Convert(m, to, on)
+		if err != nil {
+			return defaultMoney(), nil, err
+		}
+
+		total, err = total.AddErr(conv.To)
+		if err != nil {
+			return defaultMoney(), nil, err
+		}
+		conversions = append(conversions, conv)
+	}
+
+	return total, conversions, nil
+}
+
+// a bag is written as a list of its balances in order
+func (b Bag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Balances())
+}
+
+func (b *Bag) UnmarshalJSON(data []byte) error {
+	var ms []Money
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return err
+	}
+
+	r, err := NewBag(ms...)
+	if err != nil {
+		return err
+	}
+	*b = *r
+
+	return nil
+}
+
+// implements driver.Valuer, storing the bag as its JSON text
+func (b Bag) Value() (driver.Value, error) {
+	bs, err := b.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bs), nil
+}
+
+// implements sql.Scanner, reading the JSON text written by Value
+func (b *Bag) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case string:
+		return b.UnmarshalJSON([]byte(s))
+	case []byte:
+		return b.UnmarshalJSON(s)
+	default:
+		return fmt.Errorf("money: cannot scan %T into Bag", src)
+	}
+}
@@ -0,0 +1,112 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBagAddSubtract(t *testing.T) {
+	var b Bag
+	for _, m := range []Money{NewEuro(10, 0), NewEuroCent(250, 0), New(3, 0, "USD", "DOLLAR")} {
+		if err := b.Add(m); err != nil {
+			t.Fatalf("add %s: %v", m.string(), err)
+		}
+	}
+	if err := b.Subtract(New(5, 0, "USD", "DOLLAR")); err != nil {
+		t.Fatalf("subtract: %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(125, -1), b.Balance("EUR"))
+	moneyTest{t}.assertMoneyEqual(New(-2, 0, "USD", "DOLLAR"), b.Balance("usd"))
+	moneyTest{t}.assertMoneyEqual(New(0, 0, "GBP", "MAJOR"), b.Balance("GBP"))
+	if b.Balance("ZZZ").valid() {
+		t.Fatalf("expected invalid money for an unknown currency")
+	}
+
+	if got := b.Currencies(); !reflect.DeepEqual(got, []string{"EUR", "USD"}) {
+		t.Fatalf("unexpected currencies %v", got)
+	}
+	if b.IsZero() {
+		t.Fatalf("expected a non zero bag")
+	}
+}
+
+func TestBagErrors(t *testing.T) {
+	var b Bag
+	if !b.IsZero() || len(b.Balances()) != 0 {
+		t.Fatalf("expected an empty bag")
+	}
+	if err := b.Add(defaultMoney()); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+	if _, err := NewBag(NewEuro(1, 0), defaultMoney()); err == nil {
+		t.Fatalf("expected an error for invalid money")
+	}
+}
+
+func TestBagTotal(t *testing.T) {
+	p := NewMemoryRates()
+	p.Set(Rate{From: "USD", To: "EUR", Value: decimal.RequireFromString("0.9"), Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)})
+	c := Converter{Provider: p}
+
+	b, err := NewBag(NewEuro(10005, -3), New(1111, 0, "USD", "CENT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, conversions, err := b.Total(c, "EUR", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(20005, -3), total)
+	if len(conversions) != 1 || conversions[0].From.Currency() != "USD" {
+		t.Fatalf("expected only the USD balance to be converted, got %v", conversions)
+	}
+
+	if _, _, err := b.Total(c, "GBP", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+	if _, _, err := b.Total(c, "ZZZ", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
+
+func TestBagJSONAndSQL(t *testing.T) {
+	b, err := NewBag(New(3, 0, "USD", "DOLLAR"), NewEuroCent(250, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"value":"250","currency":"EUR","unit":"CENT"},{"value":"3","currency":"USD","unit":"DOLLAR"}]`
+	if string(bs) != expected {
+		t.Fatalf("expected %s, got %s", expected, bs)
+	}
+
+	var r Bag
+	if err := json.Unmarshal(bs, &r); err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(250, 0), r.Balance("EUR"))
+
+	v, err := b.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s Bag
+	if err := s.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(New(3, 0, "USD", "DOLLAR"), s.Balance("USD"))
+	if err := s.Scan(42); err == nil {
+		t.Fatalf("expected an error scanning an int")
+	}
+}
@@ -0,0 +1,138 @@
+package money
+
+import (
+	"fmt"
+	"math"
+)
+
+// returns -1, 0 or 1 as m1 is less than, equal to or greater than m2
+// m2 is converted to the unit of m1 when they differ
+func (m1 Money) Cmp(m2 Money) (int, error) {
+	if err := m1.checkCurrency("compare", m2); err != nil {
+		return 0, err
+	}
+
+	return m1.cmp(m2.in(m1.unit)), nil
+}
+
+// compares the values of money in the same unit
+func (m1 Money) cmp(m2 Money) int {
+	if m1.small && m2.small {
+		return cmpMinor(m1.minor, m2.minor)
+	}
+
+	return m1.dec().Cmp(m2.dec())
+}
+
+// returns m1 > m2
+func (m1 Money) GreaterThan(m2 Money) (bool, error) {
+	c, err := m1.Cmp(m2)
+	return c > 0, err
+}
+
+// returns m1 < m2
+func (m1 Money) LessThan(m2 Money) (bool, error) {
+	c, err := m1.Cmp(m2)
+	return c < 0, err
+}
+
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Sign() < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Sign() > 0
+}
+
+// returns -1, 0 or 1 as the money is negative, zero or positive
+func (m Money) Sign() int {
+	if m.small {
+		return cmpMinor(m.minor, 0)
+	}
+
+	return m.value.Sign()
+}
+
+func (m Money) Abs() Money {
+	if m.Sign() < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+func (m Money) Neg() Money {
+	if m.small && m.minor != math.MinInt64 {
+		m.minor = -m.minor
+		return m
+	}
+
+	return m.withDec(m.dec().Neg())
+}
+
+// returns the smallest of the money, which must all share a currency
+func Min[T Ordered[T]](ms ...T) (T, error) {
+	return extreme("min", -1, ms)
+}
+
+// returns the largest of the money, which must all share a currency
+func Max[T Ordered[T]](ms ...T) (T, error) {
+	return extreme("max", 1, ms)
+}
+
+func extreme[T Ordered[T]](op string, want int, ms []T) (T, error) {
+	if len(ms) == 0 {
+		return failed[T](), fmt.Errorf("money: %s: %w", op, ErrEmpty)
+	}
+
+	r := ms[0]
+	for _, m := range ms[1:] {
+		c, err := m.Cmp(r)
+		if err != nil {
+			return failed[T](), err
+		}
+		if c == want {
+			r = m
+		}
+	}
+
+	if r.Currency() == "" {
+		return failed[T](), fmt.Errorf("money: %s: %w", op, ErrInvalidMoney)
+	}
+
+	return r, nil
+}
+
+// orders money by currency code and then by amount, for use with sort.Slice or slices.SortFunc
+// unlike Cmp it never fails, so a mixed slice sorts into runs of each currency
+// invalid money has no code and sorts first, ordered among itself by its internal currency
+func Compare(a Money, b Money) int {
+	if a.currency != b.currency {
+		ac, bc := a.currency.string(), b.currency.string()
+		if ac < bc || (ac == bc && a.currency < b.currency) {
+			return -1
+		}
+		return 1
+	}
+
+	return a.cmp(b.in(a.unit))
+}
+
+// sorts money with Compare, e.g. sort.Sort(money.ByAmount(ms))
+type ByAmount []Money
+
+func (ms ByAmount) Len() int {
+	return len(ms)
+}
+
+func (ms ByAmount) Less(i int, j int) bool {
+	return Compare(ms[i], ms[j]) < 0
+}
+
+func (ms ByAmount) Swap(i int, j int) {
+	ms[i], ms[j] = ms[j], ms[i]
+}
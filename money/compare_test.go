@@ -0,0 +1,132 @@
+package money
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestCmp(t *testing.T) {
+	cases := []struct {
+		m1 Money
+		m2 Money
+		e  int
+	}{
+		{NewEuro(1, 0), NewEuro(2, 0), -1},
+		{NewEuro(2, 0), NewEuro(1, 0), 1},
+		{NewEuro(1, 0), NewEuroCent(100, 0), 0},
+		{NewEuroCent(101, 0), NewEuro(1, 0), 1},
+		{NewEuro(-1, 0), ZeroEuro(), -1},
+	}
+
+	for _, c := range cases {
+		r, err := c.m1.Cmp(c.m2)
+		if err != nil {
+			t.Fatalf("did not expect an error, got %v", err)
+		}
+
+		if r != c.e {
+			t.Fatalf("expected %s cmp %s to be %d but got %d", c.m1.string(), c.m2.string(), c.e, r)
+		}
+	}
+
+	if _, err := NewEuro(1, 0).Cmp(ZeroUsDollar()); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch but got %v", err)
+	}
+}
+
+func TestGreaterAndLessThan(t *testing.T) {
+	gt, err := NewEuro(2, 0).GreaterThan(NewEuroCent(150, 0))
+	if err != nil || !gt {
+		t.Fatalf("expected 2 euro > 150 cent, got %v, %v", gt, err)
+	}
+
+	lt, err := NewEuro(2, 0).LessThan(NewEuroCent(150, 0))
+	if err != nil || lt {
+		t.Fatalf("expected 2 euro not < 150 cent, got %v, %v", lt, err)
+	}
+
+	if _, err := NewEuro(2, 0).LessThan(ZeroUsDollar()); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch but got %v", err)
+	}
+}
+
+func TestSignAndPredicates(t *testing.T) {
+	neg := NewEuro(-150, -2)
+
+	if !neg.IsNegative() || neg.IsPositive() || neg.IsZero() || neg.Sign() != -1 {
+		t.Fatalf("expected %s to be negative", neg.string())
+	}
+
+	if !ZeroEuro().IsZero() || ZeroEuro().Sign() != 0 {
+		t.Fatalf("expected zero")
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(150, -2), neg.Abs())
+	moneyTest{t}.assertMoneyEqual(NewEuro(150, -2), neg.Neg())
+	moneyTest{t}.assertMoneyEqual(neg, neg.Neg().Neg())
+}
+
+func TestMinMax(t *testing.T) {
+	ms := []Money{NewEuro(5, 0), NewEuroCent(250, 0), NewEuro(7, 0), NewEuroCent(-1, 0)}
+
+	lo, err := Min(ms...)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(-1, 0), lo)
+
+	hi, err := Max(ms...)
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(7, 0), hi)
+
+	if _, err := Max[Money](); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty but got %v", err)
+	}
+
+	if _, err := Min(NewEuro(1, 0), ZeroUsDollar()); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch but got %v", err)
+	}
+
+	if _, err := Max(defaultMoney()); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+}
+
+func TestSortByAmount(t *testing.T) {
+	ms := []Money{
+		New(3, 0, "USD", "dollar"),
+		NewEuro(2, 0),
+		NewEuroCent(150, 0),
+		New(1, 0, "USD", "dollar"),
+		NewEuro(-1, 0),
+	}
+
+	sort.Sort(ByAmount(ms))
+
+	e := []Money{
+		NewEuro(-1, 0),
+		NewEuroCent(150, 0),
+		NewEuro(2, 0),
+		New(1, 0, "USD", "dollar"),
+		New(3, 0, "USD", "dollar"),
+	}
+
+	for i := range e {
+		moneyTest{t}.assertMoneyEqual(e[i], ms[i])
+	}
+}
+
+func TestCompareInvalid(t *testing.T) {
+	a, b := defaultMoney(), Money{currency: 1 << 20, unit: MAJOR}
+
+	if Compare(a, b) != -Compare(b, a) || Compare(a, b) == 0 {
+		t.Fatalf("expected opposite orders, got %d and %d", Compare(a, b), Compare(b, a))
+	}
+
+	if Compare(a, NewEuro(1, 0)) != -1 || Compare(NewEuro(1, 0), b) != 1 {
+		t.Fatalf("expected invalid money to sort first")
+	}
+}
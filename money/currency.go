@@ -0,0 +1,279 @@
+package money
+
+type currency int64
+
+// indices into the currency table, kept stable for existing callers
+const (
+	EUR currency = iota
+	USD
+)
+
+// ISO 4217 currency definition
+// exponent is the number of decimal places of the minor unit, e.g. 2 for EUR, 0 for JPY
+// major and minor optionally name the units, e.g. "EURO" and "CENT", and otherwise read as MAJOR and MINOR
+// symbol is used for display unless the locale has its own, e.g. "US$" here but "$" in en-US, and falls back to the code
+// cash is the smallest amount settled in cash in minor units where that is more than one, e.g. 5 for CHF 0.05
+// from and until bound when the currency was legal tender as "2006-01-02" dates, empty when open ended or unknown
+// a withdrawn currency names its successor, and the fixed rate as the units of it that made one successor unit,
+// e.g. "1.95583" for DEM to EUR
+type currencyInfo struct {
+	code      string
+	numeric   int
+	exponent  int32
+	name      string
+	major     string
+	minor     string
+	symbol    string
+	cash      int64
+	from      string
+	until     string
+	successor string
+	rate      string
+}
+
+// the full ISO 4217 table, along with withdrawn currencies that were replaced at a fixed rate.
+// EUR and USD lead so that the exported constants index correctly,
+// everything else is alphabetical. adding a currency is a single entry here
+// funds and precious metals have no minor unit in ISO 4217 and are given an exponent of 0
+var currencies = []currencyInfo{
+	{code: "EUR", numeric: 978, exponent: 2, name: "Euro", major: "EURO", minor: "CENT", symbol: "€", from: "1999-01-01"},
+	{code: "USD", numeric: 840, exponent: 2, name: "US Dollar", major: "DOLLAR", minor: "CENT", symbol: "US$"},
+	{code: "AED", numeric: 784, exponent: 2, name: "UAE Dirham"},
+	{code: "AFN", numeric: 971, exponent: 2, name: "Afghani"},
+	{code: "ALL", numeric: 8, exponent: 2, name: "Lek"},
+	{code: "AMD", numeric: 51, exponent: 2, name: "Armenian Dram"},
+	{code: "ANG", numeric: 532, exponent: 2, name: "Netherlands Antillean Guilder"},
+	{code: "AOA", numeric: 973, exponent: 2, name: "Kwanza"},
+	{code: "ARS", numeric: 32, exponent: 2, name: "Argentine Peso"},
+	{code: "ATS", numeric: 40, exponent: 2, name: "Austrian Schilling", until: "2002-02-28", successor: "EUR", rate: "13.7603"},
+	{code: "AUD", numeric: 36, exponent: 2, name: "Australian Dollar", symbol: "A$", cash: 5},
+	{code: "AWG", numeric: 533, exponent: 2, name: "Aruban Florin"},
+	{code: "AZN", numeric: 944, exponent: 2, name: "Azerbaijan Manat"},
+	{code: "BAM", numeric: 977, exponent: 2, name: "Convertible Mark"},
+	{code: "BBD", numeric: 52, exponent: 2, name: "Barbados Dollar"},
+	{code: "BDT", numeric: 50, exponent: 2, name: "Taka"},
+	{code: "BEF", numeric: 56, exponent: 0, name: "Belgian Franc", until: "2002-02-28", successor: "EUR", rate: "40.3399"},
+	{code: "BGN", numeric: 975, exponent: 2, name: "Bulgarian Lev"},
+	{code: "BHD", numeric: 48, exponent: 3, name: "Bahraini Dinar"},
+	{code: "BIF", numeric: 108, exponent: 0, name: "Burundi Franc"},
+	{code: "BMD", numeric: 60, exponent: 2, name: "Bermudian Dollar"},
+	{code: "BND", numeric: 96, exponent: 2, name: "Brunei Dollar"},
+	{code: "BOB", numeric: 68, exponent: 2, name: "Boliviano"},
+	{code: "BOV", numeric: 984, exponent: 2, name: "Mvdol"},
+	{code: "BRL", numeric: 986, exponent: 2, name: "Brazilian Real", symbol: "R$"},
+	{code: "BSD", numeric: 44, exponent: 2, name: "Bahamian Dollar"},
+	{code: "BTN", numeric: 64, exponent: 2, name: "Ngultrum"},
+	{code: "BWP", numeric: 72, exponent: 2, name: "Pula"},
+	{code: "BYN", numeric: 933, exponent: 2, name: "Belarusian Ruble", from: "2016-07-01"},
+	{code: "BYR", numeric: 974, exponent: 0, name: "Belarusian Ruble", from: "2000-01-01", until: "2016-06-30", successor: "BYN", rate: "10000"},
+	{code: "BZD", numeric: 84, exponent: 2, name: "Belize Dollar"},
+	{code: "CAD", numeric: 124, exponent: 2, name: "Canadian Dollar", symbol: "CA$", cash: 5},
+	{code: "CDF", numeric: 976, exponent: 2, name: "Congolese Franc"},
+	{code: "CHE", numeric: 947, exponent: 2, name: "WIR Euro"},
+	{code: "CHF", numeric: 756, exponent: 2, name: "Swiss Franc", major: "FRANC", minor: "RAPPEN", cash: 5},
+	{code: "CHW", numeric: 948, exponent: 2, name: "WIR Franc"},
+	{code: "CLF", numeric: 990, exponent: 4, name: "Unidad de Fomento"},
+	{code: "CLP", numeric: 152, exponent: 0, name: "Chilean Peso"},
+	{code: "CNY", numeric: 156, exponent: 2, name: "Yuan Renminbi", symbol: "CN¥"},
+	{code: "COP", numeric: 170, exponent: 2, name: "Colombian Peso"},
+	{code: "COU", numeric: 970, exponent: 2, name: "Unidad de Valor Real"},
+	{code: "CRC", numeric: 188, exponent: 2, name: "Costa Rican Colon"},
+	{code: "CUP", numeric: 192, exponent: 2, name: "Cuban Peso"},
+	{code: "CVE", numeric: 132, exponent: 2, name: "Cabo Verde Escudo"},
+	{code: "CYP", numeric: 196, exponent: 2, name: "Cyprus Pound", until: "2008-01-31", successor: "EUR", rate: "0.585274"},
+	{code: "CZK", numeric: 203, exponent: 2, name: "Czech Koruna", cash: 100},
+	{code: "DEM", numeric: 276, exponent: 2, name: "Deutsche Mark", from: "1948-06-20", until: "2001-12-31", successor: "EUR", rate: "1.95583"},
+	{code: "DJF", numeric: 262, exponent: 0, name: "Djibouti Franc"},
+	{code: "DKK", numeric: 208, exponent: 2, name: "Danish Krone", cash: 50},
+	{code: "DOP", numeric: 214, exponent: 2, name: "Dominican Peso"},
+	{code: "DZD", numeric: 12, exponent: 2, name: "Algerian Dinar"},
+	{code: "EEK", numeric: 233, exponent: 2, name: "Kroon", from: "1992-06-20", until: "2011-01-14", successor: "EUR", rate: "15.6466"},
+	{code: "EGP", numeric: 818, exponent: 2, name: "Egyptian Pound"},
+	{code: "ERN", numeric: 232, exponent: 2, name: "Nakfa"},
+	{code: "ESP", numeric: 724, exponent: 0, name: "Spanish Peseta", until: "2002-02-28", successor: "EUR", rate: "166.386"},
+	{code: "ETB", numeric: 230, exponent: 2, name: "Ethiopian Birr"},
+	{code: "FIM", numeric: 246, exponent: 2, name: "Markka", until: "2002-02-28", successor: "EUR", rate: "5.94573"},
+	{code: "FJD", numeric: 242, exponent: 2, name: "Fiji Dollar"},
+	{code: "FKP", numeric: 238, exponent: 2, name: "Falkland Islands Pound"},
+	{code: "FRF", numeric: 250, exponent: 2, name: "French Franc", from: "1960-01-01", until: "2002-02-17", successor: "EUR", rate: "6.55957"},
+	{code: "GBP", numeric: 826, exponent: 2, name: "Pound Sterling", major: "POUND", minor: "PENNY", symbol: "£"},
+	{code: "GEL", numeric: 981, exponent: 2, name: "Lari"},
+	{code: "GHS", numeric: 936, exponent: 2, name: "Ghana Cedi"},
+	{code: "GIP", numeric: 292, exponent: 2, name: "Gibraltar Pound"},
+	{code: "GMD", numeric: 270, exponent: 2, name: "Dalasi"},
+	{code: "GNF", numeric: 324, exponent: 0, name: "Guinean Franc"},
+	{code: "GRD", numeric: 300, exponent: 0, name: "Drachma", until: "2002-02-28", successor: "EUR", rate: "340.750"},
+	{code: "GTQ", numeric: 320, exponent: 2, name: "Quetzal"},
+	{code: "GYD", numeric: 328, exponent: 2, name: "Guyana Dollar"},
+	{code: "HKD", numeric: 344, exponent: 2, name: "Hong Kong Dollar", symbol: "HK$"},
+	{code: "HNL", numeric: 340, exponent: 2, name: "Lempira"},
+	{code: "HRK", numeric: 191, exponent: 2, name: "Kuna", from: "1994-05-30", until: "2023-01-14", successor: "EUR", rate: "7.53450"},
+	{code: "HTG", numeric: 332, exponent: 2, name: "Gourde"},
+	{code: "HUF", numeric: 348, exponent: 2, name: "Forint", cash: 500},
+	{code: "IDR", numeric: 360, exponent: 2, name: "Rupiah"},
+	{code: "IEP", numeric: 372, exponent: 2, name: "Irish Pound", until: "2002-02-09", successor: "EUR", rate: "0.787564"},
+	{code: "ILS", numeric: 376, exponent: 2, name: "New Israeli Sheqel", symbol: "₪"},
+	{code: "INR", numeric: 356, exponent: 2, name: "Indian Rupee", symbol: "₹"},
+	{code: "IQD", numeric: 368, exponent: 3, name: "Iraqi Dinar"},
+	{code: "IRR", numeric: 364, exponent: 2, name: "Iranian Rial"},
+	{code: "ISK", numeric: 352, exponent: 0, name: "Iceland Krona"},
+	{code: "ITL", numeric: 380, exponent: 0, name: "Italian Lira", until: "2002-02-28", successor: "EUR", rate: "1936.27"},
+	{code: "JMD", numeric: 388, exponent: 2, name: "Jamaican Dollar"},
+	{code: "JOD", numeric: 400, exponent: 3, name: "Jordanian Dinar"},
+	{code: "JPY", numeric: 392, exponent: 0, name: "Yen", major: "YEN", symbol: "¥"},
+	{code: "KES", numeric: 404, exponent: 2, name: "Kenyan Shilling"},
+	{code: "KGS", numeric: 417, exponent: 2, name: "Som"},
+	{code: "KHR", numeric: 116, exponent: 2, name: "Riel"},
+	{code: "KMF", numeric: 174, exponent: 0, name: "Comorian Franc"},
+	{code: "KPW", numeric: 408, exponent: 2, name: "North Korean Won"},
+	{code: "KRW", numeric: 410, exponent: 0, name: "Won", symbol: "₩"},
+	{code: "KWD", numeric: 414, exponent: 3, name: "Kuwaiti Dinar"},
+	{code: "KYD", numeric: 136, exponent: 2, name: "Cayman Islands Dollar"},
+	{code: "KZT", numeric: 398, exponent: 2, name: "Tenge"},
+	{code: "LAK", numeric: 418, exponent: 2, name: "Lao Kip"},
+	{code: "LBP", numeric: 422, exponent: 2, name: "Lebanese Pound"},
+	{code: "LKR", numeric: 144, exponent: 2, name: "Sri Lanka Rupee"},
+	{code: "LRD", numeric: 430, exponent: 2, name: "Liberian Dollar"},
+	{code: "LSL", numeric: 426, exponent: 2, name: "Loti"},
+	{code: "LTL", numeric: 440, exponent: 2, name: "Lithuanian Litas", from: "1993-06-25", until: "2015-01-15", successor: "EUR", rate: "3.45280"},
+	{code: "LUF", numeric: 442, exponent: 0, name: "Luxembourg Franc", until: "2002-02-28", successor: "EUR", rate: "40.3399"},
+	{code: "LVL", numeric: 428, exponent: 2, name: "Latvian Lats", from: "1993-03-05", until: "2014-01-14", successor: "EUR", rate: "0.702804"},
+	{code: "LYD", numeric: 434, exponent: 3, name: "Libyan Dinar"},
+	{code: "MAD", numeric: 504, exponent: 2, name: "Moroccan Dirham"},
+	{code: "MDL", numeric: 498, exponent: 2, name: "Moldovan Leu"},
+	{code: "MGA", numeric: 969, exponent: 2, name: "Malagasy Ariary"},
+	{code: "MKD", numeric: 807, exponent: 2, name: "Denar"},
+	{code: "MMK", numeric: 104, exponent: 2, name: "Kyat"},
+	{code: "MNT", numeric: 496, exponent: 2, name: "Tugrik"},
+	{code: "MOP", numeric: 446, exponent: 2, name: "Pataca"},
+	{code: "MRO", numeric: 478, exponent: 2, name: "Ouguiya", from: "1973-06-29", until: "2017-12-31", successor: "MRU", rate: "10"},
+	{code: "MRU", numeric: 929, exponent: 2, name: "Ouguiya", from: "2018-01-01"},
+	{code: "MTL", numeric: 470, exponent: 2, name: "Maltese Lira", until: "2008-01-31", successor: "EUR", rate: "0.429300"},
+	{code: "MUR", numeric: 480, exponent: 2, name: "Mauritius Rupee"},
+	{code: "MVR", numeric: 462, exponent: 2, name: "Rufiyaa"},
+	{code: "MWK", numeric: 454, exponent: 2, name: "Malawi Kwacha"},
+	{code: "MXN", numeric: 484, exponent: 2, name: "Mexican Peso", symbol: "MX$"},
+	{code: "MXV", numeric: 979, exponent: 2, name: "Mexican Unidad de Inversion (UDI)"},
+	{code: "MYR", numeric: 458, exponent: 2, name: "Malaysian Ringgit"},
+	{code: "MZN", numeric: 943, exponent: 2, name: "Mozambique Metical"},
+	{code: "NAD", numeric: 516, exponent: 2, name: "Namibia Dollar"},
+	{code: "NGN", numeric: 566, exponent: 2, name: "Naira"},
+	{code: "NIO", numeric: 558, exponent: 2, name: "Cordoba Oro"},
+	{code: "NLG", numeric: 528, exponent: 2, name: "Netherlands Guilder", until: "2002-01-28", successor: "EUR", rate: "2.20371"},
+	{code: "NOK", numeric: 578, exponent: 2, name: "Norwegian Krone", cash: 100},
+	{code: "NPR", numeric: 524, exponent: 2, name: "Nepalese Rupee"},
+	{code: "NZD", numeric: 554, exponent: 2, name: "New Zealand Dollar", symbol: "NZ$", cash: 10},
+	{code: "OMR", numeric: 512, exponent: 3, name: "Rial Omani"},
+	{code: "PAB", numeric: 590, exponent: 2, name: "Balboa"},
+	{code: "PEN", numeric: 604, exponent: 2, name: "Sol"},
+	{code: "PGK", numeric: 598, exponent: 2, name: "Kina"},
+	{code: "PHP", numeric: 608, exponent: 2, name: "Philippine Peso", symbol: "₱"},
+	{code: "PKR", numeric: 586, exponent: 2, name: "Pakistan Rupee"},
+	{code: "PLN", numeric: 985, exponent: 2, name: "Zloty"},
+	{code: "PTE", numeric: 620, exponent: 0, name: "Portuguese Escudo", until: "2002-02-28", successor: "EUR", rate: "200.482"},
+	{code: "PYG", numeric: 600, exponent: 0, name: "Guarani"},
+	{code: "QAR", numeric: 634, exponent: 2, name: "Qatari Rial"},
+	{code: "RON", numeric: 946, exponent: 2, name: "Romanian Leu"},
+	{code: "RSD", numeric: 941, exponent: 2, name: "Serbian Dinar"},
+	{code: "RUB", numeric: 643, exponent: 2, name: "Russian Ruble"},
+	{code: "RWF", numeric: 646, exponent: 0, name: "Rwanda Franc"},
+	{code: "SAR", numeric: 682, exponent: 2, name: "Saudi Riyal"},
+	{code: "SBD", numeric: 90, exponent: 2, name: "Solomon Islands Dollar"},
+	{code: "SCR", numeric: 690, exponent: 2, name: "Seychelles Rupee"},
+	{code: "SDG", numeric: 938, exponent: 2, name: "Sudanese Pound"},
+	{code: "SEK", numeric: 752, exponent: 2, name: "Swedish Krona", cash: 100},
+	{code: "SGD", numeric: 702, exponent: 2, name: "Singapore Dollar"},
+	{code: "SHP", numeric: 654, exponent: 2, name: "Saint Helena Pound"},
+	{code: "SIT", numeric: 705, exponent: 2, name: "Tolar", from: "1991-10-08", until: "2007-01-14", successor: "EUR", rate: "239.640"},
+	{code: "SKK", numeric: 703, exponent: 2, name: "Slovak Koruna", from: "1993-02-08", until: "2009-01-16", successor: "EUR", rate: "30.1260"},
+	{code: "SLE", numeric: 925, exponent: 2, name: "Leone", from: "2022-07-01"},
+	{code: "SLL", numeric: 694, exponent: 2, name: "Leone", until: "2022-06-30", successor: "SLE", rate: "1000"},
+	{code: "SOS", numeric: 706, exponent: 2, name: "Somali Shilling"},
+	{code: "SRD", numeric: 968, exponent: 2, name: "Surinam Dollar"},
+	{code: "SSP", numeric: 728, exponent: 2, name: "South Sudanese Pound"},
+	{code: "STD", numeric: 678, exponent: 2, name: "Dobra", until: "2017-12-31", successor: "STN", rate: "1000"},
+	{code: "STN", numeric: 930, exponent: 2, name: "Dobra", from: "2018-01-01"},
+	{code: "SVC", numeric: 222, exponent: 2, name: "El Salvador Colon"},
+	{code: "SYP", numeric: 760, exponent: 2, name: "Syrian Pound"},
+	{code: "SZL", numeric: 748, exponent: 2, name: "Lilangeni"},
+	{code: "THB", numeric: 764, exponent: 2, name: "Baht"},
+	{code: "TJS", numeric: 972, exponent: 2, name: "Somoni"},
+	{code: "TMT", numeric: 934, exponent: 2, name: "Turkmenistan New Manat"},
+	{code: "TND", numeric: 788, exponent: 3, name: "Tunisian Dinar"},
+	{code: "TOP", numeric: 776, exponent: 2, name: "Pa'anga"},
+	{code: "TRY", numeric: 949, exponent: 2, name: "Turkish Lira"},
+	{code: "TTD", numeric: 780, exponent: 2, name: "Trinidad and Tobago Dollar"},
+	{code: "TWD", numeric: 901, exponent: 2, name: "New Taiwan Dollar", symbol: "NT$"},
+	{code: "TZS", numeric: 834, exponent: 2, name: "Tanzanian Shilling"},
+	{code: "UAH", numeric: 980, exponent: 2, name: "Hryvnia"},
+	{code: "UGX", numeric: 800, exponent: 0, name: "Uganda Shilling"},
+	{code: "USN", numeric: 997, exponent: 2, name: "US Dollar (Next day)"},
+	{code: "UYI", numeric: 940, exponent: 0, name: "Uruguay Peso en Unidades Indexadas (UI)"},
+	{code: "UYU", numeric: 858, exponent: 2, name: "Peso Uruguayo"},
+	{code: "UYW", numeric: 927, exponent: 4, name: "Unidad Previsional"},
+	{code: "UZS", numeric: 860, exponent: 2, name: "Uzbekistan Sum"},
+	{code: "VEB", numeric: 862, exponent: 2, name: "Bolivar", until: "2007-12-31", successor: "VEF", rate: "1000"},
+	{code: "VED", numeric: 926, exponent: 2, name: "Bolivar Soberano"},
+	{code: "VEF", numeric: 937, exponent: 2, name: "Bolivar Fuerte", from: "2008-01-01", until: "2018-08-19", successor: "VES", rate: "100000"},
+	{code: "VES", numeric: 928, exponent: 2, name: "Bolivar Soberano", from: "2018-08-20"},
+	{code: "VND", numeric: 704, exponent: 0, name: "Dong", symbol: "₫"},
+	{code: "VUV", numeric: 548, exponent: 0, name: "Vatu"},
+	{code: "WST", numeric: 882, exponent: 2, name: "Tala"},
+	{code: "XAF", numeric: 950, exponent: 0, name: "CFA Franc BEAC", symbol: "FCFA"},
+	{code: "XAG", numeric: 961, exponent: 0, name: "Silver"},
+	{code: "XAU", numeric: 959, exponent: 0, name: "Gold"},
+	{code: "XBA", numeric: 955, exponent: 0, name: "Bond Markets Unit European Composite Unit (EURCO)"},
+	{code: "XBB", numeric: 956, exponent: 0, name: "Bond Markets Unit European Monetary Unit (E.M.U.-6)"},
+	{code: "XBC", numeric: 957, exponent: 0, name: "Bond Markets Unit European Unit of Account 9 (E.U.A.-9)"},
+	{code: "XBD", numeric: 958, exponent: 0, name: "Bond Markets Unit European Unit of Account 17 (E.U.A.-17)"},
+	{code: "XCD", numeric: 951, exponent: 2, name: "East Caribbean Dollar", symbol: "EC$"},
+	{code: "XDR", numeric: 960, exponent: 0, name: "SDR (Special Drawing Right)"},
+	{code: "XOF", numeric: 952, exponent: 0, name: "CFA Franc BCEAO", symbol: "F\u202fCFA"},
+	{code: "XPD", numeric: 964, exponent: 0, name: "Palladium"},
+	{code: "XPF", numeric: 953, exponent: 0, name: "CFP Franc", symbol: "CFPF"},
+	{code: "XPT", numeric: 962, exponent: 0, name: "Platinum"},
+	{code: "XSU", numeric: 994, exponent: 0, name: "Sucre"},
+	{code: "XTS", numeric: 963, exponent: 0, name: "Codes specifically reserved for testing purposes"},
+	{code: "XUA", numeric: 965, exponent: 0, name: "ADB Unit of Account"},
+	{code: "XXX", numeric: 999, exponent: 0, name: "The codes assigned for transactions where no currency is involved"},
+	{code: "YER", numeric: 886, exponent: 2, name: "Yemeni Rial"},
+	{code: "ZAR", numeric: 710, exponent: 2, name: "Rand"},
+	{code: "ZMW", numeric: 967, exponent: 2, name: "Zambian Kwacha"},
+	{code: "ZWD", numeric: 716, exponent: 2, name: "Zimbabwe Dollar", from: "1980-04-18", until: "2006-07-31", successor: "ZWN", rate: "1000"},
+	{code: "ZWG", numeric: 924, exponent: 2, name: "Zimbabwe Gold"},
+	{code: "ZWL", numeric: 932, exponent: 2, name: "Zimbabwe Dollar", from: "2009-02-03"},
+	{code: "ZWN", numeric: 942, exponent: 2, name: "Zimbabwe Dollar", from: "2006-08-01", until: "2008-07-31", successor: "ZWR", rate: "10000000000"},
+	{code: "ZWR", numeric: 935, exponent: 2, name: "Zimbabwe Dollar", from: "2008-08-01", until: "2009-02-02", successor: "ZWL", rate: "1000000000000"},
+}
+
+var currencyCodes = func() map[string]currency {
+	m := make(map[string]currency, len(currencies))
+	for i, ci := range currencies {
+		m[ci.code] = currency(i)
+	}
+	return m
+}()
+
+// resolves an ISO 4217 code, or one registered with the registry in use
+func parseCurrency(s string) (c currency, ok bool) {
+	return registry().lookup(s)
+}
+
+func (c currency) info() (ci currencyInfo, ok bool) {
+	if c >= 0 && int(c) < len(currencies) {
+		return currencies[c], true
+	}
+
+	infos := registeredInfos()
+	i := int(c) - len(currencies)
+	if c < 0 || i >= len(infos) {
+		return
+	}
+
+	return infos[i], true
+}
+
+func (c currency) string() string {
+	ci, _ := c.info()
+	return ci.code
+}
@@ -0,0 +1,78 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCurrencyTableUnique(t *testing.T) {
+	codes := map[string]bool{}
+	numerics := map[int]string{}
+	for _, ci := range currencies {
+		if codes[ci.code] {
+			t.Fatalf("duplicate currency code %s", ci.code)
+		}
+		codes[ci.code] = true
+
+		if other, ok := numerics[ci.numeric]; ok {
+			t.Fatalf("duplicate numeric code %d for %s and %s", ci.numeric, ci.code, other)
+		}
+		numerics[ci.numeric] = ci.code
+	}
+}
+
+func TestExportedCurrencyConstants(t *testing.T) {
+	if EUR.string() != "EUR" {
+		t.Fatalf(`expected "EUR" but got %s`, EUR.string())
+	}
+
+	if USD.string() != "USD" {
+		t.Fatalf(`expected "USD" but got %s`, USD.string())
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	for _, code := range []string{"GBP", "chf", "JPY", "sek", "KWD"} {
+		c, ok := parseCurrency(code)
+		if !ok {
+			t.Fatalf("expected %s to be a known currency", code)
+		}
+
+		if _, ok := c.info(); !ok {
+			t.Fatalf("expected currency info for %s", code)
+		}
+	}
+
+	if _, ok := parseCurrency("NOT"); ok {
+		t.Fatalf("did not expect NOT to be a known currency")
+	}
+}
+
+func TestNewISOCurrency(t *testing.T) {
+	m := New(1234, -2, "gbp", "penny")
+
+	if m.Currency() != "GBP" {
+		t.Fatalf(`expected "GBP" but got %s`, m.Currency())
+	}
+
+	if m.CurrencyNumeric() != 826 {
+		t.Fatalf("expected 826 but got %d", m.CurrencyNumeric())
+	}
+
+	if m.CurrencyName() != "Pound Sterling" {
+		t.Fatalf(`expected "Pound Sterling" but got %s`, m.CurrencyName())
+	}
+}
+
+func TestUnmarshalJSONISOCurrency(t *testing.T) {
+	j := `{"currency":"CHF","unit":"minor","value":"1250"}`
+
+	var m Money
+	err := json.Unmarshal([]byte(j), &m)
+
+	if err != nil {
+		t.Fatalf("error unmarshalling json")
+	}
+
+	moneyTest{t}.assertMoneyEqual(New(1250, 0, "CHF", "RAPPEN"), m)
+}
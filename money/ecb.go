@@ -0,0 +1,146 @@
+package money
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// how many days back a date without published rates, e.g. a weekend or TARGET holiday, falls back
+const ecbMaxFallback = 7
+
+// euro foreign exchange reference rates published by the ECB
+// reads both the daily (eurofxref-daily.xml) and historical (eurofxref-hist.xml, eurofxref-hist-90d.xml) files
+// rates are quoted against EUR, other pairs are crossed through EUR, e.g. USD -> GBP = EUR/GBP / EUR/USD
+type ECBRates struct {
+	days []ecbDay
+}
+
+type ecbDay struct {
+	date  time.Time
+	rates map[string]decimal.Decimal
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func ReadECB(r io.Reader) (*ECBRates, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, fmt.Errorf("money: reading ECB rates: %w", err)
+	}
+
+	p := &ECBRates{
+		days: make([]ecbDay, 0, len(env.Days)),
+	}
+
+	for _, d := range env.Days {
+		date, err := time.Parse("2006-01-02", d.Time)
+		if err != nil {
+			return nil, fmt.Errorf("money: reading ECB rates: %w", err)
+		}
+
+		day := ecbDay{
+			date:  date,
+			rates: make(map[string]decimal.Decimal, len(d.Rates)),
+		}
+
+		for _, r := range d.Rates {
+			v, err := decimal.NewFromString(r.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("money: reading ECB rate for %s on %s: %w", r.Currency, d.Time, err)
+			}
+			day.rates[strings.ToUpper(r.Currency)] = v
+		}
+
+		p.days = append(p.days, day)
+	}
+
+	if len(p.days) == 0 {
+		return nil, fmt.Errorf("money: reading ECB rates: no rates found")
+	}
+
+	// the historical files are newest first
+	sort.Slice(p.days, func(i, j int) bool {
+		return p.days[i].date.Before(p.days[j].date)
+	})
+
+	return p, nil
+}
+
+// opens and reads an ECB reference rate file, see ReadECB
+func LoadECB(path string) (*ECBRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadECB(f)
+}
+
+// returns the rate from -> to as of the given date
+// only the calendar date of on in its own location counts, e.g. 00:30 in Berlin on 2022-03-04 is 2022-03-04
+// when nothing was published on that date the previous business day with rates is used, up to a week back
+func (p *ECBRates) Rate(from string, to string, on time.Time) (Rate, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	// the published dates are UTC midnights
+	y, m, d := on.Date()
+	on = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	notFound := fmt.Errorf("money: ECB %s on %s: %w", ratePair(from, to), on.Format("2006-01-02"), ErrRateNotFound)
+
+	i := sort.Search(len(p.days), func(i int) bool {
+		return p.days[i].date.After(on)
+	})
+	if i == 0 {
+		return Rate{}, notFound
+	}
+
+	day := p.days[i-1]
+	if on.Sub(day.date) >= ecbMaxFallback*24*time.Hour {
+		return Rate{}, notFound
+	}
+
+	f, okf := day.rate(from)
+	t, okt := day.rate(to)
+	if !okf || !okt || f.IsZero() {
+		return Rate{}, notFound
+	}
+
+	v := t
+	if from != "EUR" {
+		v = t.Div(f)
+	}
+
+	return Rate{
+		From:  from,
+		To:    to,
+		Value: v,
+		Date:  day.date,
+	}, nil
+}
+
+func (d ecbDay) rate(c string) (decimal.Decimal, bool) {
+	if c == "EUR" {
+		return decimal.New(1, 0), true
+	}
+
+	v, ok := d.rates[c]
+	return v, ok
+}
@@ -0,0 +1,136 @@
+package money
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestReadECBDaily(t *testing.T) {
+	p, err := LoadECB("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	r, err := p.Rate("EUR", "USD", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.RequireFromString("1.0929")) {
+		t.Fatalf("expected 1.0929 but got %s", r.Value)
+	}
+
+	r, err = p.Rate("USD", "EUR", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.New(1, 0).Div(decimal.RequireFromString("1.0929"))) {
+		t.Fatalf("expected the inverse of 1.0929 but got %s", r.Value)
+	}
+}
+
+func TestECBCrossRate(t *testing.T) {
+	p, err := LoadECB("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	r, err := p.Rate("USD", "GBP", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	e := decimal.RequireFromString("0.82300").Div(decimal.RequireFromString("1.0929"))
+	if !r.Value.Equal(e) {
+		t.Fatalf("expected %s but got %s", e, r.Value)
+	}
+}
+
+func TestECBHistoricalFallback(t *testing.T) {
+	p, err := LoadECB("testdata/eurofxref-hist.xml")
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	// 2022-02-26 and 2022-02-27 are a weekend, 2022-03-01 and 2022-03-02 are missing from the fixture
+	cases := []struct {
+		on        time.Time
+		published time.Time
+	}{
+		{time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 2, 27, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		r, err := p.Rate("EUR", "USD", c.on)
+		if err != nil {
+			t.Fatalf("did not expect an error for %s, got %v", c.on, err)
+		}
+
+		if !r.Date.Equal(c.published) {
+			t.Fatalf("expected the rate for %s to come from %s but got %s", c.on, c.published, r.Date)
+		}
+	}
+
+	if _, err := p.Rate("EUR", "USD", time.Date(2022, 2, 24, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound but got %v", err)
+	}
+
+	if _, err := p.Rate("EUR", "USD", time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound but got %v", err)
+	}
+
+	if _, err := p.Rate("EUR", "JPY", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound but got %v", err)
+	}
+	// shortly after midnight in Berlin, still the day before in UTC
+	cet := time.FixedZone("CET", 60*60)
+	r, err := p.Rate("EUR", "USD", time.Date(2022, 3, 4, 0, 30, 0, 0, cet))
+	if err != nil || !r.Date.Equal(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the rate from 2022-03-04 but got %+v, %v", r, err)
+	}
+
+	if _, err := p.Rate("EUR", "USD", time.Date(2022, 3, 11, 0, 30, 0, 0, cet)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound a week after the last rate but got %v", err)
+	}
+}
+
+func TestECBConverter(t *testing.T) {
+	p, err := LoadECB("testdata/eurofxref-hist.xml")
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	c := Converter{Provider: p}
+
+	r, err := c.Convert(NewEuro(100, 0), "GBP", time.Date(2022, 3, 5, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(New(8230, -2, "GBP", "pound"), r.To)
+
+	if !r.Rate.Date.Equal(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected rate date 2022-03-04 but got %s", r.Rate.Date)
+	}
+}
+
+func TestReadECBBadData(t *testing.T) {
+	bad := `<Envelope><Cube><Cube time="2022-03-04"><Cube currency="USD" rate="n/a"/></Cube></Cube></Envelope>`
+
+	if _, err := ReadECB(strings.NewReader(bad)); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if _, err := ReadECB(strings.NewReader(`<Envelope></Envelope>`)); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
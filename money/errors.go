@@ -0,0 +1,79 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrUnitMismatch     = errors.New("unit mismatch")
+	ErrDivisionByZero   = errors.New("division by zero")
+	ErrInvalidMoney     = errors.New("invalid money")
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrUnknownUnit      = errors.New("unknown unit")
+	ErrMissingValue     = errors.New("missing value")
+	ErrEmpty            = errors.New("no money given")
+)
+
+// returned by the error returning arithmetic, e.g. AddErr
+// Err is one of the Err* values above, so the cause can be checked with errors.Is
+type OperationError struct {
+	Op  string
+	M1  Money
+	M2  Money
+	Err error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("money: %s %s and %s: %s", e.Op, e.M1.string(), e.M2.string(), e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+func (m1 Money) operationError(op string, m2 Money, err error) error {
+	return &OperationError{
+		Op:  op,
+		M1:  m1,
+		M2:  m2,
+		Err: err,
+	}
+}
+
+func (m1 Money) checkCurrency(op string, m2 Money) error {
+	if !m1.valid() || !m2.valid() {
+		return m1.operationError(op, m2, ErrInvalidMoney)
+	}
+
+	if !m1.sameCurrency(m2) {
+		return m1.operationError(op, m2, ErrCurrencyMismatch)
+	}
+
+	return nil
+}
+
+func (m1 Money) checkUnit(op string, m2 Money) error {
+	if err := m1.checkCurrency(op, m2); err != nil {
+		return err
+	}
+
+	if !m1.sameUnit(m2) {
+		return m1.operationError(op, m2, ErrUnitMismatch)
+	}
+
+	return nil
+}
+
+func (m1 Money) checkDivisor(op string, m2 Money) error {
+	if err := m1.checkCurrency(op, m2); err != nil {
+		return err
+	}
+
+	if m2.IsZero() {
+		return m1.operationError(op, m2, ErrDivisionByZero)
+	}
+
+	return nil
+}
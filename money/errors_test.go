@@ -0,0 +1,76 @@
+package money
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddErrCurrencyMismatch(t *testing.T) {
+	usd := New(123, 1, "USD", "dollar")
+	eur := New(123, 1, "EUR", "euro")
+
+	_, err := usd.AddErr(eur)
+
+	if !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch but got %v", err)
+	}
+
+	var oe *OperationError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected an *OperationError but got %T", err)
+	}
+
+	if oe.Op != "add" || !oe.M1.exactEqual(usd) || !oe.M2.exactEqual(eur) {
+		t.Fatalf("expected both operands on the error, got %+v", oe)
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "1230 USD") || !strings.Contains(msg, "1230 EUR") {
+		t.Fatalf("expected both operands in the message, got %s", msg)
+	}
+}
+
+func TestMultiplyErrUnitMismatch(t *testing.T) {
+	_, err := NewEuro(1, 0).MultiplyErr(NewEuroCent(1, 0))
+
+	if !errors.Is(err, ErrUnitMismatch) {
+		t.Fatalf("expected ErrUnitMismatch but got %v", err)
+	}
+}
+
+func TestDivideErrDivisionByZero(t *testing.T) {
+	_, err := NewEuro(1, 0).DivideErr(ZeroEuro())
+
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("expected ErrDivisionByZero but got %v", err)
+	}
+
+	_, err = NewEuro(1, 0).QuotientErr(ZeroEuro())
+
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("expected ErrDivisionByZero but got %v", err)
+	}
+
+	if _, ok := NewEuro(1, 0).Divide(ZeroEuro()); ok {
+		t.Fatalf("should not return ok")
+	}
+}
+
+func TestMultiplyErrInvalidMoney(t *testing.T) {
+	_, err := NewEuro(1, 0).MultiplyErr(New(1, 0, "ZZZ", "major"))
+
+	if !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+}
+
+func TestAddErr(t *testing.T) {
+	r, err := NewEuro(1, 0).AddErr(NewEuroCent(50, 0))
+
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(15, -1), r)
+}
@@ -0,0 +1,272 @@
+package money
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrUnknownLocale = errors.New("unknown locale")
+
+// CLDR style currency format data, keyed by locale e.g. "de-CH"
+// patterns follow CLDR, ¤ marks the symbol and an optional ;-separated subpattern formats negatives,
+// otherwise negatives are the positive pattern prefixed with the locale's minus sign
+//
+//go:embed locales.json
+var localeData []byte
+
+var locales = func() map[string]locale {
+	var raw map[string]struct {
+		Decimal         string            `json:"decimal"`
+		Group           string            `json:"group"`
+		Minus           string            `json:"minus"`
+		MinimumGrouping int               `json:"minimumGroupingDigits"`
+		Standard        string            `json:"standard"`
+		Accounting      string            `json:"accounting"`
+		Symbols         map[string]string `json:"symbols"`
+	}
+	if err := json.Unmarshal(localeData, &raw); err != nil {
+		panic(fmt.Sprintf("money: embedded locale data: %v", err))
+	}
+
+	ls := make(map[string]locale, len(raw))
+	for name, r := range raw {
+		l := locale{
+			decimal:         r.Decimal,
+			group:           r.Group,
+			minus:           r.Minus,
+			minimumGrouping: r.MinimumGrouping,
+			symbols:         r.Symbols,
+		}
+		if l.minus == "" {
+			l.minus = "-"
+		}
+		if l.minimumGrouping == 0 {
+			l.minimumGrouping = 1
+		}
+		l.standard = parseCurrencyPattern(r.Standard, l.minus)
+		l.accounting = parseCurrencyPattern(r.Accounting, l.minus)
+
+		for code := range l.symbols {
+			l.symbolCodes = append(l.symbolCodes, code)
+		}
+		sort.Slice(l.symbolCodes, func(i, j int) bool {
+			si, sj := l.symbols[l.symbolCodes[i]], l.symbols[l.symbolCodes[j]]
+			if len(si) != len(sj) {
+				return len(si) > len(sj)
+			}
+			return l.symbolCodes[i] < l.symbolCodes[j]
+		})
+
+		ls[name] = l
+	}
+
+	return ls
+}()
+
+type locale struct {
+	decimal         string
+	group           string
+	minus           string
+	minimumGrouping int
+	standard        currencyPattern
+	accounting      currencyPattern
+	symbols         map[string]string
+	// the codes in symbols, longest symbol first then by code, so symbols are matched in a fixed order
+	symbolCodes []string
+}
+
+// e.g. ¤#,##0.00;(¤#,##0.00)
+// primary and secondary are the grouping sizes, e.g. 3 and 2 for #,##,##0.00, and 0 when ungrouped
+type currencyPattern struct {
+	positive  affixes
+	negative  affixes
+	primary   int
+	secondary int
+}
+
+type affixes struct {
+	prefix string
+	suffix string
+}
+
+func parseCurrencyPattern(p string, minus string) currencyPattern {
+	pos, neg, hasNeg := cut(p, ";")
+
+	var cp currencyPattern
+	var number string
+	cp.positive, number = splitPattern(pos)
+
+	if hasNeg {
+		cp.negative, _ = splitPattern(neg)
+		cp.negative.prefix = strings.ReplaceAll(cp.negative.prefix, "-", minus)
+		cp.negative.suffix = strings.ReplaceAll(cp.negative.suffix, "-", minus)
+	} else {
+		cp.negative = affixes{
+			prefix: minus + cp.positive.prefix,
+			suffix: cp.positive.suffix,
+		}
+	}
+
+	integer, _, _ := cut(number, ".")
+	groups := strings.Split(integer, ",")
+	if len(groups) > 1 {
+		cp.primary = len(groups[len(groups)-1])
+		cp.secondary = cp.primary
+	}
+	if len(groups) > 2 {
+		cp.secondary = len(groups[len(groups)-2])
+	}
+
+	return cp
+}
+
+// separates a subpattern into the text around the number and the number itself
+func splitPattern(p string) (affixes, string) {
+	start := strings.IndexAny(p, "#0")
+	if start < 0 {
+		return affixes{prefix: p}, ""
+	}
+
+	end := start
+	for end < len(p) && strings.IndexByte("#0,.", p[end]) >= 0 {
+		end++
+	}
+
+	return affixes{prefix: p[:start], suffix: p[end:]}, p[start:end]
+}
+
+// accepts e.g. "de-CH", "de_CH" or "DE-ch"
+func lookupLocale(name string) (locale, bool) {
+	lang, region, _ := cut(strings.ReplaceAll(name, "_", "-"), "-")
+	l, ok := locales[strings.ToLower(lang)+"-"+strings.ToUpper(region)]
+	return l, ok
+}
+
+func (l locale) symbol(c currency) string {
+	code := c.string()
+	if s, ok := l.symbols[code]; ok {
+		return s
+	}
+
+	ci, _ := c.info()
+	if ci.symbol != "" {
+		return ci.symbol
+	}
+
+	return code
+}
+
+// formats the money for display in the given locale, e.g. "$1,234.56" in en-US, "1.234,56 €" in de-DE
+// the amount is shown in the major unit, rounded half even to the currency's minor unit
+func (m Money) FormatLocale(locale string) (string, error) {
+	l, err := m.formatLocale(locale)
+	if err != nil {
+		return "", err
+	}
+
+	return l.format(m, l.standard), nil
+}
+
+// as FormatLocale, using the locale's accounting pattern, which commonly shows negatives in parentheses
+// e.g. "($1,234.56)" in en-US
+func (m Money) FormatAccounting(locale string) (string, error) {
+	l, err := m.formatLocale(locale)
+	if err != nil {
+		return "", err
+	}
+
+	return l.format(m, l.accounting), nil
+}
+
+func (m Money) formatLocale(name string) (locale, error) {
+	if !m.valid() {
+		return locale{}, fmt.Errorf("money: format %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	l, ok := lookupLocale(name)
+	if !ok {
+		return locale{}, fmt.Errorf("money: format in %s: %w", name, ErrUnknownLocale)
+	}
+
+	return l, nil
+}
+
+func (l locale) format(m Money, p currencyPattern) string {
+	exp := m.Exponent()
+	v := m.ToMajor().dec().RoundBank(exp)
+
+	a := p.positive
+	if v.IsNegative() {
+		a = p.negative
+	}
+
+	integer, fraction, _ := cut(v.Abs().StringFixed(exp), ".")
+
+	var b strings.Builder
+	b.WriteString(withSymbol(a.prefix, l.symbol(m.currency), true))
+	b.WriteString(l.groupDigits(integer, p))
+	if fraction != "" {
+		b.WriteString(l.decimal)
+		b.WriteString(fraction)
+	}
+	b.WriteString(withSymbol(a.suffix, l.symbol(m.currency), false))
+
+	return b.String()
+}
+
+func (l locale) groupDigits(integer string, p currencyPattern) string {
+	if p.primary == 0 || len(integer) < p.primary+l.minimumGrouping {
+		return integer
+	}
+
+	groups := []string{integer[len(integer)-p.primary:]}
+	integer = integer[:len(integer)-p.primary]
+
+	for len(integer) > p.secondary {
+		groups = append([]string{integer[len(integer)-p.secondary:]}, groups...)
+		integer = integer[:len(integer)-p.secondary]
+	}
+	groups = append([]string{integer}, groups...)
+
+	return strings.Join(groups, l.group)
+}
+
+// replaces ¤ in an affix with the symbol
+// as in CLDR a no-break space separates a symbol that ends in a letter from the adjacent number, e.g. "CHF 12.00"
+func withSymbol(affix string, s string, prefix bool) string {
+	if !strings.Contains(affix, "¤") {
+		return affix
+	}
+
+	if prefix && strings.HasSuffix(affix, "¤") {
+		if r, _ := utf8.DecodeLastRuneInString(s); needsSpacing(r) {
+			s += "\u00a0"
+		}
+	}
+
+	if !prefix && strings.HasPrefix(affix, "¤") {
+		if r, _ := utf8.DecodeRuneInString(s); needsSpacing(r) {
+			s = "\u00a0" + s
+		}
+	}
+
+	return strings.Replace(affix, "¤", s, 1)
+}
+
+func needsSpacing(r rune) bool {
+	return !unicode.IsSymbol(r) && !unicode.IsSpace(r)
+}
+
+// splits s around the first sep
+func cut(s string, sep string) (before string, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatLocale(t *testing.T) {
+	cases := []struct {
+		m      Money
+		locale string
+		e      string
+	}{
+		{New(123456, -2, "USD", "dollar"), "en-US", "$1,234.56"},
+		{New(-123456, -2, "USD", "dollar"), "en-US", "-$1,234.56"},
+		{NewEuro(123456, -2), "de-DE", "1.234,56\u00a0€"},
+		{NewEuro(-123456, -2), "de-DE", "-1.234,56\u00a0€"},
+		{NewEuroCent(123456, 0), "de-DE", "1.234,56\u00a0€"},
+		{New(123456, -2, "CHF", "franc"), "fr-CH", "1\u202f234,56\u00a0CHF"},
+		{New(123456, -2, "CHF", "franc"), "de-CH", "CHF\u00a01\u2019234.56"},
+		{New(-123456, -2, "CHF", "franc"), "de-CH", "CHF-1\u2019234.56"},
+		{New(123456, -2, "CHF", "franc"), "en-US", "CHF\u00a01,234.56"},
+		{New(123456, -2, "USD", "dollar"), "en-GB", "US$1,234.56"},
+		{New(1234, 0, "JPY", "yen"), "ja-JP", "￥1,234"},
+		{New(12345, -1, "JPY", "yen"), "en-US", "¥1,234"},
+		{New(1234567, -3, "KWD", "major"), "en-US", "KWD\u00a01,234.567"},
+		{New(-123456, -2, "SEK", "major"), "sv-SE", "\u22121\u00a0234,56\u00a0kr"},
+		{NewEuro(123456, -2), "es-ES", "1234,56\u00a0€"},
+		{NewEuro(1234567, -2), "es-ES", "12.345,67\u00a0€"},
+		{New(1234567890, -2, "INR", "major"), "en-IN", "₹1,23,45,678.90"},
+		{NewEuro(5, -3), "en-IE", "€0.00"},
+		{NewEuro(-1, -3), "en-IE", "€0.00"},
+		{NewEuro(100, 0), "nl_nl", "€\u00a0100,00"},
+	}
+
+	for _, c := range cases {
+		r, err := c.m.FormatLocale(c.locale)
+		if err != nil {
+			t.Fatalf("did not expect an error, got %v", err)
+		}
+
+		if r != c.e {
+			t.Fatalf("expected %s in %s to format as %q but got %q", c.m.string(), c.locale, c.e, r)
+		}
+	}
+}
+
+func TestFormatAccounting(t *testing.T) {
+	cases := []struct {
+		m      Money
+		locale string
+		e      string
+	}{
+		{New(-123456, -2, "USD", "dollar"), "en-US", "($1,234.56)"},
+		{New(123456, -2, "USD", "dollar"), "en-US", "$1,234.56"},
+		{NewEuro(-123456, -2), "fr-FR", "(1\u202f234,56\u00a0€)"},
+		{NewEuro(-123456, -2), "de-DE", "-1.234,56\u00a0€"},
+	}
+
+	for _, c := range cases {
+		r, err := c.m.FormatAccounting(c.locale)
+		if err != nil {
+			t.Fatalf("did not expect an error, got %v", err)
+		}
+
+		if r != c.e {
+			t.Fatalf("expected %s in %s to format as %q but got %q", c.m.string(), c.locale, c.e, r)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	if _, err := NewEuro(1, 0).FormatLocale("xx-XX"); !errors.Is(err, ErrUnknownLocale) {
+		t.Fatalf("expected ErrUnknownLocale but got %v", err)
+	}
+
+	if _, err := defaultMoney().FormatLocale("en-US"); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+}
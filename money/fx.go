@@ -0,0 +1,229 @@
+package money
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrRateNotFound = errors.New("rate not found")
+
+// exchange rate where 1 From = Value To
+// Date is the date the rate was published for, which can be earlier than the date that was asked for
+type Rate struct {
+	From  string
+	To    string
+	Value decimal.Decimal
+	Date  time.Time
+}
+
+// source of exchange rates, e.g. an in memory table or a central bank feed
+type RateProvider interface {
+	// returns the rate from -> to in effect on the given date, or an error wrapping ErrRateNotFound
+	Rate(from string, to string, on time.Time) (Rate, error)
+}
+
+// the outcome of a conversion, along with the rate that was applied so it can be audited
+type Conversion struct {
+	From Money
+	To   Money
+	Rate Rate
+	// the date the conversion was asked for, Rate.Date holds the date of the rate that was used
+	On time.Time
+}
+
+// converts money between currencies using rates from Provider
+// converted amounts are expressed in the major unit of the target currency
+// and rounded to its minor unit with Rounding
+// without a Provider only conversions to the same currency succeed
+type Converter struct {
+	Provider RateProvider
+	Rounding RoundingMode
+}
+
+func (c Converter) Convert(m Money, to string, on time.Time) (Conversion, error) {
+	if !m.valid() {
+		return Conversion{}, fmt.Errorf("money: convert %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	target, ok := parseCurrency(to)
+	if !ok {
+		return Conversion{}, fmt.Errorf("money: convert to %s: %w", to, ErrUnknownCurrency)
+	}
+
+	rate := Rate{
+		From:  m.currency.string(),
+		To:    target.string(),
+		Value: decimal.New(1, 0),
+		Date:  on,
+	}
+
+	if m.currency != target {
+		if c.Provider == nil {
+			return Conversion{}, fmt.Errorf("money: convert %s without a rate provider: %w", ratePair(rate.From, rate.To), ErrRateNotFound)
+		}
+
+		var err error
+		rate, err = c.Provider.Rate(rate.From, rate.To, on)
+		if err != nil {
+			return Conversion{}, err
+		}
+	}
+
+	ci, _ := target.info()
+	v := m.ToMajor().dec().Mul(rate.Value)
+
+	return Conversion{
+		From: m,
+		To: Money{
+			value:    c.Rounding.round(v, ci.exponent),
+			currency: target,
+			unit:     MAJOR,
+		}.packed(),
+		Rate: rate,
+		On:   on,
+	}, nil
+}
+
+// in memory rate table, safe for concurrent use
+// a pair is answered with the latest rate on or before the date asked for
+// if only the opposite pair is known its inverse is used
+// the zero value is an empty table ready to use
+type MemoryRates struct {
+	mu    sync.RWMutex
+	rates map[string][]Rate
+}
+
+func NewMemoryRates() *MemoryRates {
+	return &MemoryRates{
+		rates: make(map[string][]Rate),
+	}
+}
+
+func ratePair(from string, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
+
+// adds a rate, replacing any existing rate for the same pair and date
+func (p *MemoryRates) Set(r Rate) {
+	r.From = strings.ToUpper(r.From)
+	r.To = strings.ToUpper(r.To)
+	key := ratePair(r.From, r.To)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rs := p.rates[key]
+	i := sort.Search(len(rs), func(i int) bool {
+		return !rs[i].Date.Before(r.Date)
+	})
+
+	if i < len(rs) && rs[i].Date.Equal(r.Date) {
+		rs[i] = r
+		return
+	}
+
+	rs = append(rs, Rate{})
+	copy(rs[i+1:], rs[i:])
+	rs[i] = r
+	if p.rates == nil {
+		p.rates = make(map[string][]Rate)
+	}
+	p.rates[key] = rs
+}
+
+func (p *MemoryRates) Rate(from string, to string, on time.Time) (Rate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if r, ok := p.latest(ratePair(from, to), on); ok {
+		return r, nil
+	}
+
+	if r, ok := p.latest(ratePair(to, from), on); ok && !r.Value.IsZero() {
+		return Rate{
+			From:  r.To,
+			To:    r.From,
+			Value: decimal.New(1, 0).Div(r.Value),
+			Date:  r.Date,
+		}, nil
+	}
+
+	return Rate{}, fmt.Errorf("money: %s on %s: %w", ratePair(from, to), on.Format("2006-01-02"), ErrRateNotFound)
+}
+
+func (p *MemoryRates) latest(key string, on time.Time) (Rate, bool) {
+	rs := p.rates[key]
+	i := sort.Search(len(rs), func(i int) bool {
+		return rs[i].Date.After(on)
+	})
+
+	if i == 0 {
+		return Rate{}, false
+	}
+
+	return rs[i-1], true
+}
+
+// reads a static rates file into a MemoryRates
+// each line holds date,from,to,rate e.g. 2022-03-01,EUR,USD,1.1174
+// lines starting with # are ignored, as is a leading date,from,to,rate header
+func ReadRates(r io.Reader) (*MemoryRates, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 4
+	cr.TrimLeadingSpace = true
+
+	p := NewMemoryRates()
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("money: reading rates: %w", err)
+		}
+
+		if first && strings.EqualFold(rec[0], "date") {
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		date, err := time.Parse("2006-01-02", rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("money: reading rates line %d: %w", line, err)
+		}
+
+		v, err := decimal.NewFromString(rec[3])
+		if err != nil {
+			return nil, fmt.Errorf("money: reading rates line %d: %w", line, err)
+		}
+
+		p.Set(Rate{
+			From:  rec[1],
+			To:    rec[2],
+			Value: v,
+			Date:  date,
+		})
+	}
+}
+
+// opens and reads a static rates file, see ReadRates
+func LoadRates(path string) (*MemoryRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadRates(f)
+}
@@ -0,0 +1,159 @@
+package money
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestConvert(t *testing.T) {
+	p := NewMemoryRates()
+	p.Set(Rate{From: "EUR", To: "USD", Value: decimal.RequireFromString("1.1174"), Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)})
+
+	c := Converter{Provider: p}
+
+	r, err := c.Convert(NewEuro(1000, -2), "usd", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(New(1117, -2, "USD", "dollar"), r.To)
+
+	if !r.Rate.Date.Equal(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected rate date 2022-03-01 but got %s", r.Rate.Date)
+	}
+}
+
+func TestConvertMinorUnit(t *testing.T) {
+	p := NewMemoryRates()
+	p.Set(Rate{From: "EUR", To: "JPY", Value: decimal.RequireFromString("128.57"), Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)})
+
+	c := Converter{Provider: p}
+
+	r, err := c.Convert(NewEuroCent(1050, 0), "JPY", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	// 10.50 * 128.57 = 1349.985
+	moneyTest{t}.assertMoneyEqual(New(1350, 0, "JPY", "yen"), r.To)
+
+	c.Rounding = RoundNone
+
+	r, err = c.Convert(NewEuroCent(1050, 0), "JPY", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(New(1349985, -3, "JPY", "yen"), r.To)
+}
+
+func TestConvertSameCurrency(t *testing.T) {
+	c := Converter{Provider: NewMemoryRates()}
+
+	r, err := c.Convert(NewEuroCent(1050, 0), "EUR", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(1050, -2), r.To)
+}
+
+func TestConvertErrors(t *testing.T) {
+	c := Converter{Provider: NewMemoryRates()}
+
+	_, err := c.Convert(NewEuro(1, 0), "USD", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound but got %v", err)
+	}
+
+	_, err = c.Convert(NewEuro(1, 0), "ZZZ", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency but got %v", err)
+	}
+
+	_, err = c.Convert(defaultMoney(), "USD", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+
+	_, err = Converter{}.Convert(NewEuro(1, 0), "USD", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound without a provider but got %v", err)
+	}
+}
+
+func TestMemoryRatesZeroValue(t *testing.T) {
+	var p MemoryRates
+	p.Set(Rate{From: "EUR", To: "USD", Value: decimal.RequireFromString("1.25"), Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)})
+
+	r, err := p.Rate("EUR", "USD", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || !r.Value.Equal(decimal.RequireFromString("1.25")) {
+		t.Fatalf("expected the rate that was set but got %+v, %v", r, err)
+	}
+}
+
+func TestMemoryRatesLatestAndInverse(t *testing.T) {
+	p := NewMemoryRates()
+	p.Set(Rate{From: "EUR", To: "USD", Value: decimal.RequireFromString("1.25"), Date: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)})
+	p.Set(Rate{From: "EUR", To: "USD", Value: decimal.RequireFromString("1.20"), Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)})
+	p.Set(Rate{From: "EUR", To: "USD", Value: decimal.RequireFromString("1.30"), Date: time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)})
+
+	r, err := p.Rate("EUR", "USD", time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.RequireFromString("1.25")) || !r.Date.Equal(time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the 2022-03-02 rate but got %+v", r)
+	}
+
+	r, err = p.Rate("USD", "EUR", time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.RequireFromString("0.8")) || r.From != "USD" || r.To != "EUR" {
+		t.Fatalf("expected the inverse rate but got %+v", r)
+	}
+
+	if _, err := p.Rate("EUR", "USD", time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound but got %v", err)
+	}
+}
+
+func TestLoadRates(t *testing.T) {
+	p, err := LoadRates("testdata/rates.csv")
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	r, err := p.Rate("EUR", "GBP", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.RequireFromString("0.83555")) {
+		t.Fatalf("expected 0.83555 but got %s", r.Value)
+	}
+
+	r, err = p.Rate("EUR", "USD", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if !r.Value.Equal(decimal.RequireFromString("1.1121")) {
+		t.Fatalf("expected 1.1121 but got %s", r.Value)
+	}
+}
+
+func TestReadRatesBadLine(t *testing.T) {
+	_, err := ReadRates(strings.NewReader("2022-03-01,EUR,USD,1.1\n2022-03-02,EUR,USD,abc\n"))
+
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error on line 2 but got %v", err)
+	}
+}
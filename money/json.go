@@ -0,0 +1,168 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// controls how money is written to and read from JSON
+// by default money is an object, e.g. {"value":"12.34","currency":"EUR","unit":"EURO"}
+// malformed input, a bad value and a missing or unknown currency are always errors,
+// a missing or unknown unit falls back to the major unit unless Strict
+type JSONCodec struct {
+	// also reject an unknown unit, a missing value and unknown fields
+	Strict bool
+	// write the value as a JSON number rather than a string, both are read either way
+	ValueAsNumber bool
+	// write money as a single string, e.g. "12.34 EUR", with the unit appended when it is not the major unit,
+	// e.g. "1234 EUR CENT". both forms are read either way
+	Compact bool
+	// registry whose codes are read, the one in use when nil
+	Registry *Registry
+}
+
+// the codec used by Money's MarshalJSON and UnmarshalJSON
+var DefaultJSONCodec = JSONCodec{}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return DefaultJSONCodec.Marshal(m)
+}
+
+// null leaves the money unchanged
+func (m *Money) UnmarshalJSON(data []byte) error {
+	return DefaultJSONCodec.Unmarshal(data, m)
+}
+
+// invalid money is written as it always was, with an empty currency and unit,
+// e.g. {"value":"0","currency":"","unit":""} or "0" in the compact form, and reading it back is an error
+func (c JSONCodec) Marshal(m Money) ([]byte, error) {
+	if c.Compact {
+		if !m.valid() {
+			return json.Marshal(m.dec().String())
+		}
+
+		s := m.dec().String() + " " + m.currency.string()
+		if m.unit != MAJOR {
+			s += " " + m.unit.string(m.currency)
+		}
+		return json.Marshal(s)
+	}
+
+	var v interface{} = m.dec().String()
+	if c.ValueAsNumber {
+		v = json.Number(m.dec().String())
+	}
+
+	return json.Marshal(struct {
+		Value    interface{} `json:"value"`
+		Currency string      `json:"currency"`
+		Unit     string      `json:"unit"`
+	}{
+		Value:    v,
+		Currency: m.currency.string(),
+		Unit:     m.unit.string(m.currency),
+	})
+}
+
+func (c JSONCodec) Unmarshal(data []byte, m *Money) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	var r Money
+	var err error
+	if len(data) > 0 && data[0] == '"' {
+		r, err = c.unmarshalCompact(data)
+	} else {
+		r, err = c.unmarshalObject(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	*m = r
+	return nil
+}
+
+func (c JSONCodec) unmarshalObject(data []byte) (Money, error) {
+	var tmp struct {
+		Value    *decimal.Decimal `json:"value"`
+		Currency string           `json:"currency"`
+		Unit     *string          `json:"unit"`
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	if c.Strict {
+		d.DisallowUnknownFields()
+	}
+
+	if err := d.Decode(&tmp); err != nil {
+		return defaultMoney(), fmt.Errorf("money: json: %w", err)
+	}
+
+	if tmp.Value == nil && c.Strict {
+		return defaultMoney(), fmt.Errorf("money: json: %w", ErrMissingValue)
+	}
+
+	var v decimal.Decimal
+	if tmp.Value != nil {
+		v = *tmp.Value
+	}
+
+	unit := ""
+	if tmp.Unit != nil {
+		unit = *tmp.Unit
+	}
+
+	return c.money(v, tmp.Currency, unit, tmp.Unit != nil)
+}
+
+func (c JSONCodec) unmarshalCompact(data []byte) (Money, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return defaultMoney(), fmt.Errorf("money: json: %w", err)
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) < 2 || len(fields) > 3 {
+		return defaultMoney(), fmt.Errorf("money: json: %q is not of the form \"12.34 EUR\": %w", s, ErrInvalidMoney)
+	}
+
+	v, err := decimal.NewFromString(fields[0])
+	if err != nil {
+		return defaultMoney(), fmt.Errorf("money: json: %q: %w", s, err)
+	}
+
+	unit := ""
+	if len(fields) == 3 {
+		unit = fields[2]
+	}
+
+	return c.money(v, fields[1], unit, len(fields) == 3)
+}
+
+func (c JSONCodec) money(v decimal.Decimal, code string, unit string, hasUnit bool) (Money, error) {
+	cur, ok := registryOr(c.Registry).lookup(code)
+	if !ok {
+		return defaultMoney(), fmt.Errorf("money: json: currency %q: %w", code, ErrUnknownCurrency)
+	}
+
+	u, ok := parseUnit(unit, cur)
+	if !ok {
+		if c.Strict && hasUnit {
+			return defaultMoney(), fmt.Errorf("money: json: unit %q for %s: %w", unit, cur.string(), ErrUnknownUnit)
+		}
+		u = MAJOR
+	}
+
+	return Money{
+		value:    v,
+		currency: cur,
+		unit:     u,
+	}.packed(), nil
+}
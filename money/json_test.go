@@ -0,0 +1,163 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalJSONForm(t *testing.T) {
+	bs, err := json.Marshal(NewEuroCent(1234, 0))
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	e := `{"value":"1234","currency":"EUR","unit":"CENT"}`
+	if string(bs) != e {
+		t.Fatalf("expected %s but got %s", e, bs)
+	}
+
+	for c, e := range map[JSONCodec]string{
+		{}:              `{"value":"0","currency":"","unit":""}`,
+		{Compact: true}: `"0"`,
+	} {
+		bs, err := c.Marshal(defaultMoney())
+		if err != nil || string(bs) != e {
+			t.Fatalf("expected invalid money as %s but got %s, %v", e, bs, err)
+		}
+
+		var m Money
+		if err := c.Unmarshal(bs, &m); err == nil {
+			t.Fatalf("expected an error reading %s back", bs)
+		}
+	}
+}
+
+func TestJSONCodecForms(t *testing.T) {
+	m := NewEuro(1234, -2)
+
+	cases := []struct {
+		codec JSONCodec
+		m     Money
+		e     string
+	}{
+		{JSONCodec{ValueAsNumber: true}, m, `{"value":12.34,"currency":"EUR","unit":"EURO"}`},
+		{JSONCodec{Compact: true}, m, `"12.34 EUR"`},
+		{JSONCodec{Compact: true}, NewEuroCent(1234, 0), `"1234 EUR CENT"`},
+		{JSONCodec{Compact: true}, New(-5, 0, "JPY", "yen"), `"-5 JPY"`},
+	}
+
+	for _, c := range cases {
+		bs, err := c.codec.Marshal(c.m)
+		if err != nil {
+			t.Fatalf("did not expect an error, got %v", err)
+		}
+
+		if string(bs) != c.e {
+			t.Fatalf("expected %s but got %s", c.e, bs)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	ms := []Money{
+		NewEuro(1234, -2),
+		NewEuroCent(1234, 0),
+		NewEuro(-1, -9),
+		New(123456789012345678, -4, "USD", "dollar"),
+		New(7, 0, "JPY", "yen"),
+		New(1001, -3, "KWD", "minor"),
+		ZeroUsDollar(),
+	}
+
+	codecs := []JSONCodec{
+		{},
+		{Strict: true},
+		{ValueAsNumber: true},
+		{Strict: true, ValueAsNumber: true},
+		{Compact: true},
+		{Strict: true, Compact: true},
+	}
+
+	for _, c := range codecs {
+		for _, m := range ms {
+			bs, err := c.Marshal(m)
+			if err != nil {
+				t.Fatalf("did not expect an error, got %v", err)
+			}
+
+			var r Money
+			if err := c.Unmarshal(bs, &r); err != nil {
+				t.Fatalf("did not expect an error reading %s, got %v", bs, err)
+			}
+
+			moneyTest{t}.assertMoneyEqual(m, r)
+		}
+	}
+}
+
+func TestJSONUnmarshalForms(t *testing.T) {
+	cases := []string{
+		`{"value":"12.34","currency":"EUR","unit":"EURO"}`,
+		`{"value":12.34,"currency":"eur"}`,
+		`{"Value":"12.34","Currency":"EUR","Unit":"EURO"}`,
+		`"12.34 EUR"`,
+		` "12.34 EUR EURO" `,
+	}
+
+	for _, c := range cases {
+		var m Money
+		if err := json.Unmarshal([]byte(c), &m); err != nil {
+			t.Fatalf("did not expect an error reading %s, got %v", c, err)
+		}
+
+		moneyTest{t}.assertMoneyEqual(NewEuro(1234, -2), m)
+	}
+}
+
+func TestJSONStrict(t *testing.T) {
+	strict := JSONCodec{Strict: true}
+
+	bad := []struct {
+		b string
+		e error
+	}{
+		{`{"currency":"EUR","unit":"EURO"}`, ErrMissingValue},
+		{`{"value":"1","currency":"EUR","unit":"EURO","extra":1}`, nil},
+		{`{"value":"1","currency":"EUR","unit":"DOLLAR"}`, ErrUnknownUnit},
+		{`"1 EUR DOLLAR"`, ErrUnknownUnit},
+	}
+
+	for _, c := range bad {
+		b := c.b
+		var m Money
+		err := strict.Unmarshal([]byte(b), &m)
+		if err == nil || c.e != nil && !errors.Is(err, c.e) {
+			t.Fatalf("expected %v reading %s but got %v", c.e, b, err)
+		}
+
+		if err := (JSONCodec{}).Unmarshal([]byte(b), &m); err != nil {
+			t.Fatalf("did not expect a lenient error reading %s, got %v", b, err)
+		}
+	}
+
+	always := []string{
+		`{"value":"1","currency":"XYZ"}`,
+		`{"value":"1"}`,
+		`{"value":"abc","currency":"EUR"}`,
+		`{"value":`,
+		`"1"`,
+		`"abc EUR"`,
+		`"1 XYZ"`,
+		`[]`,
+	}
+
+	for _, b := range always {
+		for _, c := range []JSONCodec{{}, strict} {
+			var m Money
+			if err := c.Unmarshal([]byte(b), &m); err == nil {
+				t.Fatalf("expected an error reading %s", b)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrNoSuccessor = errors.New("no successor at a fixed rate")
+
+// when a currency was legal tender, and what replaced it at a fixed rate
+type CurrencyHistory struct {
+	Code string
+	// zero when open ended or not known
+	From  time.Time
+	Until time.Time
+	// the currency that replaced it, empty for a current currency
+	Successor string
+	// the units of this currency that made one unit of the successor, e.g. 1.95583 for DEM to EUR
+	Rate decimal.Decimal
+}
+
+// returns the history of the currency
+func History(code string) (CurrencyHistory, error) {
+	c, ok := parseCurrency(code)
+	if !ok {
+		return CurrencyHistory{}, fmt.Errorf("money: history of %s: %w", code, ErrUnknownCurrency)
+	}
+
+	ci, _ := c.info()
+	h := CurrencyHistory{
+		Code:      ci.code,
+		From:      historyDate(ci.from),
+		Until:     historyDate(ci.until),
+		Successor: ci.successor,
+	}
+	if ci.rate != "" {
+		h.Rate = decimal.RequireFromString(ci.rate)
+	}
+
+	return h, nil
+}
+
+func historyDate(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(fmt.Sprintf("money: bad date %q in the currency table", s))
+	}
+
+	return t
+}
+
+// whether the currency was legal tender on the given date, taking unknown bounds as open
+func (h CurrencyHistory) ValidOn(on time.Time) bool {
+	y, m, d := on.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	if !h.From.IsZero() && day.Before(h.From) {
+		return false
+	}
+	if !h.Until.IsZero() && day.After(h.Until) {
+		return false
+	}
+
+	return true
+}
+
+// whether the money's currency was legal tender on the given date
+func (m Money) ValidOn(on time.Time) bool {
+	h, err := History(m.Currency())
+	return err == nil && h.ValidOn(on)
+}
+
+// restates money in a withdrawn currency in its successor at the fixed rate, following a chain of
+// successors when needed, e.g. ZWD -> ZWN -> ZWR -> ZWL
+// a step that divides exactly, as a redenomination by a power of ten does, is kept exact,
+// otherwise it is rounded half up to the successor's minor unit as the euro conversion rules require
+// the result is in the major unit of to
+func ConvertLegacy(m Money, to string) (Money, error) {
+	if !m.valid() {
+		return defaultMoney(), fmt.Errorf("money: convert legacy %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	target, ok := parseCurrency(to)
+	if !ok {
+		return defaultMoney(), fmt.Errorf("money: convert legacy %s to %s: %w", m.string(), to, ErrUnknownCurrency)
+	}
+
+	r := m.ToMajor()
+	for r.currency != target {
+		ci, _ := r.currency.info()
+		if ci.successor == "" {
+			return defaultMoney(), fmt.Errorf("money: convert legacy %s to %s: %w", m.string(), target.string(), ErrNoSuccessor)
+		}
+
+		next, ok := parseCurrency(ci.successor)
+		if !ok {
+			return defaultMoney(), fmt.Errorf("money: convert legacy %s to %s: %w", m.string(), ci.successor, ErrUnknownCurrency)
+		}
+
+		rate := decimal.RequireFromString(ci.rate)
+		v := r.dec()
+		ni, _ := next.info()
+		q := v.DivRound(rate, 2*maxExponent)
+		if rounded := q.Round(ni.exponent); rounded.Equal(q) || !q.Mul(rate).Equal(v) {
+			q = rounded
+		}
+
+		r = zero(next, MAJOR).withDec(q)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,130 @@
+package money
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestConvertLegacy(t *testing.T) {
+	cases := []struct {
+		in       Money
+		to       string
+		expected Money
+	}{
+		{New(100, 0, "DEM", "MAJOR"), "EUR", NewEuro(5113, -2)},
+		{New(1000000, 0, "ITL", "MAJOR"), "EUR", NewEuro(51646, -2)},
+		{New(100, 0, "FRF", "MINOR"), "EUR", NewEuro(15, -2)},
+		{New(195583, 0, "DEM", "MINOR"), "EUR", NewEuro(1000, 0)},
+		{New(753450, -2, "HRK", "MAJOR"), "EUR", NewEuro(1000, 0)},
+		{New(100000, 0, "VEF", "MAJOR"), "VES", New(1, 0, "VES", "MAJOR")},
+		{New(123, -2, "VEF", "MAJOR"), "VES", New(123, -7, "VES", "MAJOR")},
+		{New(100000000, 0, "VEB", "MAJOR"), "VES", New(1, 0, "VES", "MAJOR")},
+		{New(1, 21, "ZWD", "MAJOR"), "ZWL", New(1, -4, "ZWL", "MAJOR")},
+		{New(1, 0, "ZWD", "MINOR"), "ZWL", New(1, -27, "ZWL", "MAJOR")},
+		{NewEuro(5, 0), "EUR", NewEuro(5, 0)},
+	}
+
+	for _, c := range cases {
+		r, err := ConvertLegacy(c.in, c.to)
+		if err != nil {
+			t.Fatalf("%s: %v", c.in.string(), err)
+		}
+		moneyTest{t}.assertMoneyEqual(c.expected, r)
+	}
+
+	// an exact result in whole minor units takes the int64 form
+	if r, _ := ConvertLegacy(New(1955830, 0, "DEM", "MINOR"), "EUR"); !r.small {
+		t.Fatalf("expected the int64 form, got %s", r.string())
+	}
+
+	errs := []struct {
+		in  Money
+		to  string
+		err error
+	}{
+		{New(1, 0, "DEM", "MAJOR"), "FRF", ErrNoSuccessor},
+		{NewEuro(1, 0), "DEM", ErrNoSuccessor},
+		{New(1, 0, "DEM", "MAJOR"), "ZZZ", ErrUnknownCurrency},
+		{defaultMoney(), "EUR", ErrInvalidMoney},
+	}
+
+	for _, c := range errs {
+		if _, err := ConvertLegacy(c.in, c.to); !errors.Is(err, c.err) {
+			t.Fatalf("%s to %s: expected %v, got %v", c.in.string(), c.to, c.err, err)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	h, err := History("dem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Code != "DEM" || h.Successor != "EUR" || !h.Rate.Equal(decimal.RequireFromString("1.95583")) {
+		t.Fatalf("unexpected history %+v", h)
+	}
+	if !h.Until.Equal(time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected until %s", h.Until)
+	}
+
+	cases := []struct {
+		code  string
+		on    time.Time
+		valid bool
+	}{
+		{"DEM", time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC), true},
+		{"DEM", time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"DEM", time.Date(1948, 6, 19, 0, 0, 0, 0, time.UTC), false},
+		{"EUR", time.Date(1998, 12, 31, 0, 0, 0, 0, time.UTC), false},
+		{"EUR", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"VEF", time.Date(2018, 8, 20, 0, 0, 0, 0, time.UTC), false},
+		{"VES", time.Date(2018, 8, 20, 0, 0, 0, 0, time.UTC), true},
+		{"USD", time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, c := range cases {
+		h, err := History(c.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.ValidOn(c.on) != c.valid {
+			t.Fatalf("%s on %s: expected valid %v", c.code, c.on.Format("2006-01-02"), c.valid)
+		}
+	}
+
+	if !New(1, 0, "FRF", "MAJOR").ValidOn(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)) || New(1, 0, "FRF", "MAJOR").ValidOn(time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected validity for FRF")
+	}
+	if _, err := History("ZZZ"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
+
+// every withdrawn currency leads to a current one
+func TestSuccessorChains(t *testing.T) {
+	for _, ci := range currencies {
+		seen := map[string]bool{}
+		code := ci.code
+		for {
+			if seen[code] {
+				t.Fatalf("%s: successor loop at %s", ci.code, code)
+			}
+			seen[code] = true
+
+			h, err := History(code)
+			if err != nil {
+				t.Fatalf("%s: %v", ci.code, err)
+			}
+			if h.Successor == "" {
+				break
+			}
+			if !h.Rate.IsPositive() || h.Until.IsZero() {
+				t.Fatalf("%s: withdrawn without a rate or an end date", code)
+			}
+			code = h.Successor
+		}
+	}
+}
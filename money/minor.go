@@ -0,0 +1,160 @@
+package money
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// money is held as an int64 count of minor units whenever the value fits one, which keeps the common
+// arithmetic free of allocations, and as a decimal.Decimal otherwise, e.g. for 1.005 EUR or overflow
+// the two forms behave identically, every read of the value goes through dec
+
+// the decimal places of the money's unit taken by one minor unit, e.g. 2 for EUR euro, 0 for EUR cent
+func (m Money) scale() int32 {
+	if m.unit == MINOR {
+		return 0
+	}
+
+	ci, _ := m.currency.info()
+	return ci.exponent
+}
+
+// scale for a unit of a currency already looked up
+func (u unit) scale(ci currencyInfo) int32 {
+	if u == MINOR {
+		return 0
+	}
+
+	return ci.exponent
+}
+
+// returns the value in the money's unit
+func (m Money) dec() decimal.Decimal {
+	if m.small {
+		return decimal.New(m.minor, -m.scale())
+	}
+
+	return m.value
+}
+
+// dec for a money whose scale is already known
+func (m Money) decAt(scale int32) decimal.Decimal {
+	if m.small {
+		return decimal.New(m.minor, -scale)
+	}
+
+	return m.value
+}
+
+// returns m with value v, in the int64 form when v is a whole number of minor units that fits
+func (m Money) withDec(v decimal.Decimal) Money {
+	m.value = v
+	m.small = false
+	m.minor = 0
+
+	return m.packed()
+}
+
+func (m Money) packed() Money {
+	if m.small {
+		return m
+	}
+
+	ci, ok := m.currency.info()
+	if !ok || (m.unit != MAJOR && m.unit != MINOR) {
+		return m
+	}
+
+	return m.packedAt(m.unit.scale(ci))
+}
+
+// packed for a valid money in decimal form whose scale is already known
+func (m Money) packedAt(scale int32) Money {
+	// the value is coefficient * 10^exponent, so the count of minor units is coefficient * 10^k
+	// a negative k is taken as a fraction of a minor unit without looking for trailing zeros,
+	// which keeps the check cheap for values that stay in decimal form
+	k := m.value.Exponent() + scale
+	if k < 0 || k > 18 {
+		return m
+	}
+
+	c := m.value.Coefficient()
+	if !c.IsInt64() {
+		return m
+	}
+
+	v, ok := shiftMinor(c.Int64(), k)
+	if !ok {
+		return m
+	}
+
+	return Money{minor: v, small: true, currency: m.currency, unit: m.unit}
+}
+
+// returns m1 + m2, or m1 - m2 when sub, in the unit of m1, for money checked to share a currency
+// the currency is looked up once for both units, and as decimal addition keeps the finer exponent,
+// packing a result with an operand holding a fraction of a minor unit stops at the exponent check
+func (m1 Money) addDec(m2 Money, sub bool) Money {
+	ci, _ := m1.currency.info()
+	s1, s2 := m1.unit.scale(ci), m2.unit.scale(ci)
+
+	// a count of minor units reads the same in either unit, a decimal value is shifted between them
+	d2 := m2.decAt(s1)
+	if !m2.small && s1 != s2 {
+		d2 = m2.value.Shift(s2 - s1)
+	}
+
+	// minor units are put at the finer exponent of m1 where they fit, sparing the addition a rescale
+	if e := m1.value.Exponent(); m2.small && !m1.small && e < -s1 {
+		if v, ok := shiftMinor(m2.minor, -s1-e); ok {
+			d2 = decimal.New(v, e)
+		}
+	}
+
+	v := m1.decAt(s1)
+	if sub {
+		v = v.Sub(d2)
+	} else {
+		v = v.Add(d2)
+	}
+
+	return Money{value: v, currency: m1.currency, unit: m1.unit}.packedAt(s1)
+}
+
+// returns zero in the given currency and unit
+func zero(c currency, u unit) Money {
+	return Money{small: true, currency: c, unit: u}
+}
+
+// returns v * 10^k for k >= 0, ok being false on overflow
+func shiftMinor(v int64, k int32) (int64, bool) {
+	for ; k > 0; k-- {
+		if v > math.MaxInt64/10 || v < math.MinInt64/10 {
+			return 0, false
+		}
+		v *= 10
+	}
+
+	return v, true
+}
+
+// returns a + b, ok being false on overflow
+func addMinor(a int64, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+
+	return a + b, true
+}
+
+func cmpMinor(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,213 @@
+package money
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// money held as a decimal, as every value was before the int64 form
+func decimalForm(v decimal.Decimal, c currency, u unit) Money {
+	return Money{value: v, currency: c, unit: u}
+}
+
+func TestPacked(t *testing.T) {
+	cases := []struct {
+		m     Money
+		small bool
+	}{
+		{NewEuro(1234, -2), true},
+		{NewEuroCent(1234, 0), true},
+		{NewEuro(1005, -3), false},
+		{NewEuroCent(15, -1), false},
+		{New(1, 0, "JPY", "MAJOR"), true},
+		{New(math.MaxInt64, 0, "EUR", "CENT"), true},
+		{New(math.MaxInt64, 0, "EUR", "EURO"), false},
+		{ZeroEuro(), true},
+	}
+
+	for _, c := range cases {
+		if c.m.small != c.small {
+			t.Fatalf("%s: expected small %v", c.m.string(), c.small)
+		}
+	}
+}
+
+func TestMinorOverflow(t *testing.T) {
+	max := New(math.MaxInt64, 0, "EUR", "CENT")
+	min := New(math.MinInt64, 0, "EUR", "CENT")
+	one := NewEuroCent(1, 0)
+
+	r, err := max.AddErr(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := decimal.NewFromInt(math.MaxInt64).Add(decimal.NewFromInt(1))
+	if r.small || !r.ValueDecimal().Equal(e) {
+		t.Fatalf("expected %s in decimal form, got %s", e, r.string())
+	}
+
+	r, err = min.SubtractErr(one)
+	if err != nil || r.small {
+		t.Fatalf("expected decimal form, got %s, %v", r.string(), err)
+	}
+
+	r, err = NewEuroCent(0, 0).SubtractErr(min)
+	if err != nil || r.small || !r.ValueDecimal().Equal(decimal.NewFromInt(math.MinInt64).Neg()) {
+		t.Fatalf("unexpected %s, %v", r.string(), err)
+	}
+	if n := min.Neg(); n.small || n.Sign() != 1 {
+		t.Fatalf("unexpected %s", n.string())
+	}
+
+	// back within range the int64 form returns
+	r, _ = r.SubtractErr(NewEuro(1, 0))
+	if !r.small {
+		t.Fatalf("expected int64 form, got %s", r.string())
+	}
+}
+
+// every operation gives the same result whichever form its operands are in
+func TestMinorMatchesDecimal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	values := []int64{0, 1, -1, 99, 100, -250, math.MaxInt64, math.MinInt64, math.MaxInt64 / 2}
+	for i := 0; i < 200; i++ {
+		values = append(values, rnd.Int63n(2000000)-1000000)
+	}
+
+	for i := 0; i < 2000; i++ {
+		a := values[rnd.Intn(len(values))]
+		b := values[rnd.Intn(len(values))]
+		ua, ub := unit(rnd.Intn(2)), unit(rnd.Intn(2))
+
+		fa := Money{minor: a, small: true, currency: EUR, unit: ua}
+		fb := Money{minor: b, small: true, currency: EUR, unit: ub}
+		da := decimalForm(fa.dec(), EUR, ua)
+		db := decimalForm(fb.dec(), EUR, ub)
+
+		assertSame := func(op string, f Money, d Money) {
+			if !f.exactEqual(d) || f.string() != d.string() {
+				t.Fatalf("%s %s and %s: %s != %s", op, fa.string(), fb.string(), f.string(), d.string())
+			}
+		}
+
+		f, _ := fa.AddErr(fb)
+		d, _ := da.AddErr(db)
+		assertSame("add", f, d)
+
+		f, _ = fa.SubtractErr(fb)
+		d, _ = da.SubtractErr(db)
+		assertSame("subtract", f, d)
+
+		assertSame("neg", fa.Neg(), da.Neg())
+		assertSame("abs", fa.Abs(), da.Abs())
+		assertSame("major", fa.ToMajor(), da.ToMajor())
+		assertSame("minor", fa.ToMinor(), da.ToMinor())
+
+		cf, _ := fa.Cmp(fb)
+		cd, _ := da.Cmp(db)
+		if cf != cd || fa.Equal(fb) != da.Equal(db) || fa.Sign() != da.Sign() || Compare(fa, fb) != Compare(da, db) {
+			t.Fatalf("compare %s and %s differs between forms", fa.string(), fb.string())
+		}
+	}
+}
+
+// adding minor units to a fraction of a minor unit gives the decimal result, whichever unit either is in
+func TestMinorAddsToFraction(t *testing.T) {
+	fractions := []Money{NewEuro(5, -3), NewEuroCent(-15, -1), NewEuro(1, -20), New(math.MaxInt64, -3, "EUR", "EURO")}
+	minors := []int64{0, 1234, -99, math.MaxInt64, math.MinInt64}
+
+	for _, f := range fractions {
+		for _, v := range minors {
+			for _, u := range []unit{MAJOR, MINOR} {
+				m := Money{minor: v, small: true, currency: EUR, unit: u}
+				d := decimalForm(m.dec(), EUR, u)
+
+				for _, op := range []string{"add", "subtract"} {
+					r, _ := f.AddErr(m)
+					e, _ := f.AddErr(d)
+					if op == "subtract" {
+						r, _ = f.SubtractErr(m)
+						e, _ = f.SubtractErr(d)
+					}
+					if !r.exactEqual(e) || r.string() != e.string() {
+						t.Fatalf("%s %s and %s: %s != %s", op, f.string(), m.string(), r.string(), e.string())
+					}
+				}
+			}
+		}
+	}
+}
+
+// the arithmetic before the int64 form, kept to benchmark against
+func addDecimal(m1 Money, m2 Money) Money {
+	m2 = m2.in(m1.unit)
+	return Money{
+		value:    m1.value.Add(m2.value),
+		unit:     m1.unit,
+		currency: m1.currency,
+	}.autoRound()
+}
+
+var benchResult Money
+
+func BenchmarkAdd(b *testing.B) {
+	b.Run("int64", func(b *testing.B) {
+		m, step := NewEuro(0, 0), NewEuroCent(1234, 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m, _ = m.AddErr(step)
+		}
+		benchResult = m
+	})
+
+	b.Run("decimal", func(b *testing.B) {
+		m, step := decimalForm(decimal.Zero, EUR, EURO), decimalForm(decimal.New(1234, 0), EUR, CENT)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m = addDecimal(m, step)
+		}
+		benchResult = m
+	})
+
+	b.Run("fallback", func(b *testing.B) {
+		m, step := NewEuro(5, -3), NewEuroCent(1234, 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m, _ = m.AddErr(step)
+		}
+		benchResult = m
+	})
+}
+
+func BenchmarkCmp(b *testing.B) {
+	b.Run("int64", func(b *testing.B) {
+		m1, m2 := NewEuro(1234, -2), NewEuroCent(1235, 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m1.Cmp(m2)
+		}
+	})
+
+	b.Run("decimal", func(b *testing.B) {
+		m1, m2 := decimalForm(decimal.New(1234, -2), EUR, EURO), decimalForm(decimal.New(1235, 0), EUR, CENT)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m1.value.Cmp(m2.in(m1.unit).value)
+		}
+	})
+}
+
+func BenchmarkSum(b *testing.B) {
+	ms := make([]Money, 1000)
+	for i := range ms {
+		ms[i] = NewEuroCent(int64(i), 0)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult, _ = Sum(ms)
+	}
+}
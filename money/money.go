@@ -1,8 +1,8 @@
 package money
 
 import (
-	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 
@@ -10,78 +10,69 @@ import (
 )
 
 type Money struct {
+	// the value in unit when small is false, otherwise the value is minor, see dec
 	value    decimal.Decimal
+	minor    int64
+	small    bool
 	currency currency
 	unit     unit
 }
 
-type currency int64
+// whether a value is held in the major unit of its currency (e.g. euro) or the minor unit (e.g. cent)
+// the relation between the two is taken from the currency's exponent
+type unit int64
 
 const (
-	EUR currency = iota
-	USD
+	MINOR unit = iota
+	MAJOR
 )
 
-func parseCurrency(s string) (c currency, ok bool) {
-	s = strings.ToUpper(s)
-	ok = true
-	switch s {
-	case "EUR":
-		c = EUR
-	case "USD":
-		c = USD
-	default:
-		c = -1
-		ok = false
-	}
-
-	return
-}
-
-func (c currency) string() (s string) {
-	switch c {
-	case EUR:
-		s = "EUR"
-	case USD:
-		s = "USD"
-	}
-	return
-}
-
-type unit int64
-
+// names from before units were derived per currency
 const (
-	CENT unit = iota
-	EURO
-	DOLLAR
+	CENT   = MINOR
+	EURO   = MAJOR
+	DOLLAR = MAJOR
 )
 
-func parseUnit(s string) (u unit, ok bool) {
+// accepts MAJOR and MINOR for any currency, as well as the currency's own unit names
+// e.g. "euro" and "cent" for EUR
+// currencies without a minor unit (exponent 0) only have a major unit
+func parseUnit(s string, c currency) (u unit, ok bool) {
+	ci, okc := c.info()
+	if !okc {
+		return -1, false
+	}
+
 	s = strings.ToUpper(s)
-	ok = true
-	switch s {
-	case "CENT":
-		u = CENT
-	case "DOLLAR":
-		u = DOLLAR
-	case "EURO":
-		u = EURO
+	switch {
+	case s == "MAJOR" || (ci.major != "" && s == ci.major):
+		u = MAJOR
+	case s == "MINOR" || (ci.minor != "" && s == ci.minor):
+		u = MINOR
 	default:
-		u = -1
-		ok = false
+		return -1, false
 	}
 
-	return
+	if ci.exponent == 0 {
+		u = MAJOR
+	}
+
+	return u, true
 }
 
-func (u unit) string() (s string) {
+func (u unit) string(c currency) (s string) {
+	ci, _ := c.info()
 	switch u {
-	case CENT:
-		s = "CENT"
-	case EURO:
-		s = "EURO"
-	case DOLLAR:
-		s = "DOLLAR"
+	case MAJOR:
+		s = "MAJOR"
+		if ci.major != "" {
+			s = ci.major
+		}
+	case MINOR:
+		s = "MINOR"
+		if ci.minor != "" {
+			s = ci.minor
+		}
 	}
 	return
 }
@@ -93,9 +84,39 @@ func defaultMoney() Money {
 	}
 }
 
+// whether the money has a known currency and unit, i.e. is not the result of a failed operation
+func (m Money) valid() bool {
+	_, ok := m.currency.info()
+	return ok && (m.unit == MAJOR || m.unit == MINOR)
+}
+
+// returns the invalid money the package returns alongside an error, with no currency or unit
+// packages building on money return it the same way
+func Invalid() Money {
+	return defaultMoney()
+}
+
+// whether the money has a known currency and unit, false for Invalid and the result of a failed operation
+func (m Money) IsValid() bool {
+	return m.valid()
+}
+
+// e.g. 12.34 EUR (EURO), used in error messages
+func (m Money) string() string {
+	if !m.valid() {
+		return "invalid money"
+	}
+
+	return fmt.Sprintf("%s %s (%s)", m.dec().String(), m.currency.string(), m.unit.string(m.currency))
+}
+
 func new(v decimal.Decimal, c string, u string) Money {
-	currency, okc := parseCurrency(c)
-	unit, oku := parseUnit(u)
+	return registry().new(v, c, u)
+}
+
+func (r *Registry) new(v decimal.Decimal, c string, u string) Money {
+	currency, okc := r.lookup(c)
+	unit, oku := parseUnit(u, currency)
 
 	if !okc || !oku {
 		return defaultMoney()
@@ -105,7 +126,7 @@ func new(v decimal.Decimal, c string, u string) Money {
 		value:    v,
 		currency: currency,
 		unit:     unit,
-	}
+	}.packed()
 }
 
 func newEuro(v decimal.Decimal) Money {
@@ -132,21 +153,15 @@ func NewFromFloat(f float64, c string, u string) Money {
 	return new(d, c, u)
 }
 
+func NewFromDecimal(d decimal.Decimal, c string, u string) Money {
+	return new(d, c, u)
+}
+
+// creates money in the major unit of the currency
 func NewDefaultFromFloat(f float64, c string) Money {
 	d := decimal.NewFromFloat(f)
 
-	c = strings.ToUpper(c)
-	var u string
-	switch c {
-	case "EUR":
-		u = "euro"
-	case "USD":
-		u = "dollar"
-	default:
-		return defaultMoney()
-	}
-
-	return new(d, c, u)
+	return new(d, c, "MAJOR")
 }
 
 func NewEuro(val int64, exp int32) Money {
@@ -187,27 +202,14 @@ func (m1 Money) exactEqual(m2 Money) bool {
 	return m1.sameValue(m2) && m1.sameUnit(m2)
 }
 
-// evalutates whether two money structs share the same value, irrespective of the underlying currency
+// evalutates whether two money structs share the same value, irrespective of the underlying unit
 // e.g. 100 EUR cent == 1 EUR euro
 func (m1 Money) Equal(m2 Money) bool {
 	if !m1.sameCurrency(m2) {
 		return false
 	}
 
-	if m1.sameUnit(m2) {
-		return m1.sameValue(m2)
-	}
-
-	// scale both to cent to evaluate
-	if m1.unit == EURO || m1.unit == DOLLAR {
-		m1.value = m1.value.Mul(decimal.New(10, 0))
-		m1.unit = CENT
-	} else if m2.unit == EURO || m2.unit == DOLLAR {
-		m2.value = m2.value.Mul(decimal.New(10, 0))
-		m2.unit = CENT
-	}
-
-	return m1.exactEqual(m2)
+	return m1.exactEqual(m2.in(m1.unit))
 }
 
 func (m1 Money) EqualCurrency(m2 Money) bool {
@@ -222,7 +224,11 @@ func (m1 Money) EqualUnit(m2 Money) bool {
 }
 
 func (m1 Money) sameValue(m2 Money) bool {
-	return m1.value.Equal(m2.value)
+	if m1.small && m2.small && m1.scale() == m2.scale() {
+		return m1.minor == m2.minor
+	}
+
+	return m1.dec().Equal(m2.dec())
 }
 
 func (m1 Money) sameCurrency(m2 Money) bool {
@@ -234,93 +240,129 @@ func (m1 Money) sameUnit(m2 Money) bool {
 }
 
 // returns m1 + m2, ok
+// m2 is converted to the unit of m1 when they differ
 func (m1 Money) Add(m2 Money) (Money, bool) {
-	if !m1.sameUnit(m2) {
-		return defaultMoney(), false
+	m, err := m1.AddErr(m2)
+	return m, err == nil
+}
+
+// returns m1 + m2, or an *OperationError describing why they could not be added
+// m2 is converted to the unit of m1 when they differ
+func (m1 Money) AddErr(m2 Money) (Money, error) {
+	if err := m1.checkCurrency("add", m2); err != nil {
+		return defaultMoney(), err
 	}
 
-	return Money{
-		value:    m1.value.Add(m2.value),
-		unit:     m1.unit,
-		currency: m1.currency,
-	}, true
+	// a count of minor units reads the same in either unit
+	if m1.small && m2.small {
+		if v, ok := addMinor(m1.minor, m2.minor); ok {
+			m1.minor = v
+			return m1.autoRound(), nil
+		}
+	}
+
+	return m1.addDec(m2, false).autoRound(), nil
 }
 
 // returns m1 - m2, ok
+// m2 is converted to the unit of m1 when they differ
 func (m1 Money) Subtract(m2 Money) (Money, bool) {
-	if !m1.sameUnit(m2) {
-		return defaultMoney(), false
+	m, err := m1.SubtractErr(m2)
+	return m, err == nil
+}
+
+// returns m1 - m2, or an *OperationError describing why they could not be subtracted
+// m2 is converted to the unit of m1 when they differ
+func (m1 Money) SubtractErr(m2 Money) (Money, error) {
+	if err := m1.checkCurrency("subtract", m2); err != nil {
+		return defaultMoney(), err
 	}
 
-	return Money{
-		value:    m1.value.Sub(m2.value),
-		unit:     m1.unit,
-		currency: m1.currency,
-	}, true
+	if m1.small && m2.small && m2.minor != math.MinInt64 {
+		if v, ok := addMinor(m1.minor, -m2.minor); ok {
+			m1.minor = v
+			return m1.autoRound(), nil
+		}
+	}
+
+	return m1.addDec(m2, true).autoRound(), nil
 }
 
 // returns m1 * m2, ok
+// unlike the other operations both must share a unit, as a cent * euro product has no meaningful unit
 func (m1 Money) Multiply(m2 Money) (Money, bool) {
-	if !m1.sameUnit(m2) {
-		return defaultMoney(), false
+	m, err := m1.MultiplyErr(m2)
+	return m, err == nil
+}
+
+// returns m1 * m2, or an *OperationError describing why they could not be multiplied
+func (m1 Money) MultiplyErr(m2 Money) (Money, error) {
+	if err := m1.checkUnit("multiply", m2); err != nil {
+		return defaultMoney(), err
 	}
 
-	return Money{
-		value:    m1.value.Mul(m2.value),
-		unit:     m1.unit,
-		currency: m1.currency,
-	}, true
+	return m1.withDec(m1.dec().Mul(m2.dec())).autoRound(), nil
 }
 
 func (m Money) MultiplyFloat(f float64) Money {
-	return Money{
-		value:    m.value.Mul(decimal.NewFromFloat(f)),
-		unit:     m.unit,
-		currency: m.currency,
-	}
+	return m.withDec(m.dec().Mul(decimal.NewFromFloat(f))).autoRound()
 }
 
 // returns m1 / m2, ok
+// m2 is converted to the unit of m1 when they differ
 func (m1 Money) Divide(m2 Money) (Money, bool) {
-	if !m1.sameUnit(m2) {
-		return defaultMoney(), false
+	m, err := m1.DivideErr(m2)
+	return m, err == nil
+}
+
+// returns m1 / m2, or an *OperationError describing why they could not be divided
+// m2 is converted to the unit of m1 when they differ
+func (m1 Money) DivideErr(m2 Money) (Money, error) {
+	if err := m1.checkDivisor("divide", m2); err != nil {
+		return defaultMoney(), err
 	}
+	m2 = m2.in(m1.unit)
 
-	return Money{
-		value:    m1.value.Div(m2.value),
-		unit:     m1.unit,
-		currency: m1.currency,
-	}, true
+	return m1.withDec(m1.dec().Div(m2.dec())).autoRound(), nil
 }
 
+// returns the integer quotient of m1 / m2, ok
+// m2 is converted to the unit of m1 when they differ
 func (m1 Money) Quotient(m2 Money) (int64, bool) {
-	if !m1.sameUnit(m2) {
-		return 0, false
+	q, err := m1.QuotientErr(m2)
+	return q, err == nil
+}
+
+// returns the integer quotient of m1 / m2, or an *OperationError describing why they could not be divided
+func (m1 Money) QuotientErr(m2 Money) (int64, error) {
+	if err := m1.checkDivisor("quotient", m2); err != nil {
+		return 0, err
 	}
+	m2 = m2.in(m1.unit)
 
-	q, _ := m1.value.QuoRem(m2.value, 0)
+	q, _ := m1.dec().QuoRem(m2.dec(), 0)
 
-	return q.IntPart(), true
+	return q.IntPart(), nil
 }
 
 func (m Money) QutoientFloat(f float64) int64 {
-	q, _ := m.value.QuoRem(decimal.NewFromFloat(f), 0)
+	q, _ := m.dec().QuoRem(decimal.NewFromFloat(f), 0)
 	return q.IntPart()
 }
 
 func (m Money) ValueDecimal() decimal.Decimal {
-	return m.value
+	return m.dec()
 }
 
 // returns float64 representation of the money, and flag indicating if this value is exact
 func (m Money) ValueFloat64() (val float64, exact bool) {
-	val, exact = m.value.Float64()
+	val, exact = m.dec().Float64()
 	return
 }
 
 // returns big int representation of the money
 func (m Money) ValueBigInt() *big.Int {
-	return m.value.BigInt()
+	return m.dec().BigInt()
 }
 
 // returns the currency
@@ -328,69 +370,69 @@ func (m Money) Currency() string {
 	return m.currency.string()
 }
 
+// returns the ISO 4217 numeric code of the currency
+func (m Money) CurrencyNumeric() int {
+	ci, _ := m.currency.info()
+	return ci.numeric
+}
+
+// returns the ISO 4217 display name of the currency
+func (m Money) CurrencyName() string {
+	ci, _ := m.currency.info()
+	return ci.name
+}
+
 func (m Money) Unit() string {
-	return m.unit.string()
-}
-
-func (m Money) MarshalJSON() ([]byte, error) {
-	bs, erj := json.Marshal(struct {
-		Value    decimal.Decimal
-		Currency string
-		Unit     string
-	}{
-		Value:    m.value,
-		Currency: m.currency.string(),
-		Unit:     m.unit.string(),
-	})
-	if erj != nil {
-		return nil, erj
-	}
+	return m.unit.string(m.currency)
+}
 
-	return bs, nil
+// returns the number of decimal places of the currency's minor unit, e.g. 2 for EUR, 0 for JPY
+func (m Money) Exponent() int32 {
+	ci, _ := m.currency.info()
+	return ci.exponent
 }
 
-func (m *Money) UnmarshalJSON(data []byte) error {
-	if string(data) == "null" {
-		return nil
-	}
-	var tmp struct {
-		Value    decimal.Decimal `json:"value"`
-		Currency string          `json:"currency"`
-		Unit     string          `json:"unit"`
-	}
-	err := json.Unmarshal(data, &tmp)
+// returns the money expressed in the major unit of its currency
+func (m Money) ToMajor() Money {
+	return m.in(MAJOR)
+}
 
-	if err != nil {
-		return nil
-	}
+// returns the money expressed in the minor unit of its currency
+// the major unit for a currency without a minor unit, e.g. JPY
+func (m Money) ToMinor() Money {
+	return m.in(MINOR)
+}
 
-	c, okc := parseCurrency(tmp.Currency)
-	u, oku := parseUnit(string(tmp.Unit))
+// converts between units of the same currency, one major unit is 10^exponent minor units
+func (m Money) in(u unit) Money {
+	if m.unit == u {
+		return m
+	}
 
-	// must have a currency
-	if !okc {
-		return nil
+	ci, ok := m.currency.info()
+	if !ok || (u != MAJOR && u != MINOR) {
+		return m
 	}
 
-	// default to base unit if undefined
-	if !oku {
-		switch c {
-		case EUR:
-			u = EURO
-		case USD:
-			u = DOLLAR
-		default:
-			err = fmt.Errorf("could not derive default unit for provided currency %s", c.string())
-		}
+	// currencies without a minor unit only have a major unit, as in parseUnit
+	if ci.exponent == 0 {
+		m.unit = MAJOR
+		return m
 	}
 
-	if err != nil {
-		return err
+	// a count of minor units reads the same in either unit
+	if m.small {
+		m.unit = u
+		return m
 	}
 
-	m.value = tmp.Value
-	m.currency = c
+	switch u {
+	case MAJOR:
+		m.value = m.value.Shift(-ci.exponent)
+	case MINOR:
+		m.value = m.value.Shift(ci.exponent)
+	}
 	m.unit = u
 
-	return nil
+	return m
 }
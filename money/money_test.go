@@ -2,6 +2,7 @@ package money
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -55,7 +56,7 @@ func TestNewFromFloat(t *testing.T) {
 
 	e = defaultMoney()
 
-	r = NewFromFloat(528.2900, "NZD", "dollar")
+	r = NewFromFloat(528.2900, "ZZZ", "dollar")
 
 	moneyTest{t}.assertMoneyEqual(e, r)
 }
@@ -83,7 +84,7 @@ func TestNewDefaultFromFloat(t *testing.T) {
 
 	e = defaultMoney()
 
-	r = NewDefaultFromFloat(512.00, "NZD")
+	r = NewDefaultFromFloat(512.00, "ZZZ")
 
 	moneyTest{t}.assertMoneyEqual(e, r)
 }
@@ -148,6 +149,18 @@ func TestNewEuroFromDecimal(t *testing.T) {
 	moneyTest{t}.assertMoneyEqual(e, r)
 }
 
+func TestNewFromDecimal(t *testing.T) {
+	e := Money{
+		value:    decimal.New(4539, -2),
+		currency: USD,
+		unit:     MINOR,
+	}
+
+	r := NewFromDecimal(decimal.New(4539, -2), "USD", "CENT")
+
+	moneyTest{t}.assertMoneyEqual(e, r)
+}
+
 func TestNewEuroCent(t *testing.T) {
 	e := Money{
 		value:    decimal.New(583920, -1),
@@ -413,8 +426,8 @@ func TestBadDataUnmarshalJSON(t *testing.T) {
 	var m1 Money
 	err := json.Unmarshal([]byte(badValueJSON), &m1)
 
-	if err != nil {
-		t.Fatalf("did not expect an error")
+	if err == nil {
+		t.Fatalf("expected an error")
 	}
 	moneyTest{t}.assertMoneyEqual(e1, m1)
 
@@ -423,8 +436,8 @@ func TestBadDataUnmarshalJSON(t *testing.T) {
 	var m2 Money
 	err = json.Unmarshal([]byte(badCurrencyJSON), &m2)
 
-	if err != nil {
-		t.Fatalf("did not expect an error")
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency but got %v", err)
 	}
 	moneyTest{t}.assertMoneyEqual(e2, m2)
 
@@ -501,3 +514,108 @@ func TestQutientFloat(t *testing.T) {
 		t.Fatalf("expected %d got %d", e, r)
 	}
 }
+
+func TestEqualAcrossUnits(t *testing.T) {
+	if !NewEuro(1, 0).Equal(NewEuroCent(100, 0)) {
+		t.Fatal("expected 1 euro to equal 100 cent")
+	}
+
+	if !NewEuroCent(250, 0).Equal(NewEuro(25, -1)) {
+		t.Fatal("expected 250 cent to equal 2.5 euro")
+	}
+
+	if NewEuro(1, 0).Equal(NewEuroCent(10, 0)) {
+		t.Fatal("did not expect 1 euro to equal 10 cent")
+	}
+
+	kwd := New(1, 0, "KWD", "major")
+	fils := New(1000, 0, "KWD", "minor")
+
+	if !kwd.Equal(fils) {
+		t.Fatal("expected 1 KWD to equal 1000 fils")
+	}
+
+	if !New(500, 0, "JPY", "yen").Equal(New(500, 0, "JPY", "minor")) {
+		t.Fatal("expected JPY to only have a major unit")
+	}
+
+	if New(100, 0, "USD", "cent").Equal(NewEuroCent(100, 0)) {
+		t.Fatal("did not expect USD cent to equal EUR cent")
+	}
+}
+
+func TestUnitConversion(t *testing.T) {
+	m := NewEuroCent(12345, 0)
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(12345, -2), m.ToMajor())
+	moneyTest{t}.assertMoneyEqual(m, m.ToMajor().ToMinor())
+
+	if m.Exponent() != 2 {
+		t.Fatalf("expected exponent 2 but got %d", m.Exponent())
+	}
+
+	jpy := New(500, 0, "JPY", "yen")
+	if jpy.Exponent() != 0 {
+		t.Fatalf("expected exponent 0 but got %d", jpy.Exponent())
+	}
+
+	if jpy.Unit() != "YEN" {
+		t.Fatalf(`expected "YEN" but got %s`, jpy.Unit())
+	}
+
+	// without a minor unit the minor unit is the major unit
+	moneyTest{t}.assertMoneyEqual(jpy, jpy.ToMinor())
+
+	gbp := New(1, 0, "GBP", "minor")
+	if gbp.Unit() != "PENNY" {
+		t.Fatalf(`expected "PENNY" but got %s`, gbp.Unit())
+	}
+
+	sek := New(1, 0, "SEK", "major")
+	if sek.Unit() != "MAJOR" {
+		t.Fatalf(`expected "MAJOR" but got %s`, sek.Unit())
+	}
+}
+
+func TestAddAcrossUnits(t *testing.T) {
+	r, ok := NewEuro(1, 0).Add(NewEuroCent(50, 0))
+
+	if !ok {
+		t.Fatalf("expected same currency to add")
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(15, -1), r)
+}
+
+func TestSubtractAcrossUnits(t *testing.T) {
+	r, ok := NewEuroCent(250, 0).Subtract(NewEuro(1, 0))
+
+	if !ok {
+		t.Fatalf("expected same currency to subtract")
+	}
+
+	// result takes the unit of the receiver
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(150, 0), r)
+}
+
+func TestDivideAcrossUnits(t *testing.T) {
+	r, ok := NewEuro(3, 0).Divide(NewEuroCent(150, 0))
+
+	if !ok {
+		t.Fatalf("expected same currency to divide")
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(2, 0), r)
+}
+
+func TestQuotientAcrossUnits(t *testing.T) {
+	r, ok := NewEuroCent(1000, 0).Quotient(NewEuro(3, 0))
+
+	if !ok {
+		t.Fatalf("expected same currency to divide")
+	}
+
+	if r != 3 {
+		t.Fatalf("expected 3 but got %d", r)
+	}
+}
@@ -0,0 +1,634 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+type ParseOptions struct {
+	// locale whose separators, grouping and symbols are expected, e.g. "de-DE"
+	// without one the decimal separator is worked out from the input, see Parse
+	Locale string
+	// currency used when the input has neither a code nor a symbol
+	Currency string
+	// only accept input in a form FormatLocale or FormatAccounting would produce for Locale,
+	// with either the locale's symbol or the ISO code, which must then be upper case
+	// this requires a locale
+	Strict bool
+	// registry whose codes and symbols are known, the one in use when nil
+	Registry *Registry
+}
+
+// describes why input could not be parsed, Pos is the byte offset in Input where parsing failed
+// Err is set when the failure has a matching Err* value, e.g. ErrUnknownCurrency
+type ParseError struct {
+	Input string
+	Pos   int
+	Msg   string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("money: parse %q at position %d: %s", e.Input, e.Pos, e.Msg)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parses human entered money such as "€1.234,50", "USD 12.00", "-$3.5", "(12.50 EUR)" or "12,50 EUR"
+// the currency is taken from an ISO code or a symbol in the input and otherwise from opts.Currency
+// money is returned in the major unit of its currency
+//
+// in lenient mode codes are case insensitive, any space or apostrophe is accepted as a group separator,
+// grouping is optional and any number of decimals is kept
+// groups must use a single separator and have the locale's sizes, or without a locale three digits,
+// allowing for two digit secondary groups as in 1,23,45,678
+// a bare symbol such as $ resolves to the locale's currency for it, or to opts.Currency when that uses it
+// without a locale the form String writes, e.g. "1.005 EUR", always reads back as written
+// otherwise the last . or , is the decimal separator, unless it appears once after digits other than a lone 0
+// and is followed by exactly three digits in a currency with fewer decimals, e.g. "1,234 USD" is 1234,
+// "1,234 KWD" is 1.234 and "0,125 USD" is 0.125
+func Parse(s string, opts ParseOptions) (Money, error) {
+	p := parser{
+		input: s,
+		opts:  opts,
+		reg:   registryOr(opts.Registry),
+	}
+
+	if opts.Locale != "" {
+		l, ok := lookupLocale(opts.Locale)
+		if !ok {
+			return defaultMoney(), fmt.Errorf("money: parse in %s: %w", opts.Locale, ErrUnknownLocale)
+		}
+		p.loc = &l
+	}
+
+	if opts.Strict && p.loc == nil {
+		return defaultMoney(), fmt.Errorf("money: strict parsing needs a locale")
+	}
+
+	return p.parse()
+}
+
+type parser struct {
+	input string
+	opts  ParseOptions
+	reg   *Registry
+	loc   *locale
+}
+
+// text either side of the number, with the sign, parentheses and currency marker picked out
+type parsedAffix struct {
+	text      string
+	pos       int
+	sign      rune
+	signPos   int
+	open      bool
+	close     bool
+	marker    string
+	markerPos int
+}
+
+func (p parser) fail(pos int, err error, format string, args ...interface{}) error {
+	return &ParseError{
+		Input: p.input,
+		Pos:   pos,
+		Msg:   fmt.Sprintf(format, args...),
+		Err:   err,
+	}
+}
+
+func (p parser) parse() (Money, error) {
+	if p.loc == nil {
+		if m, ok := p.canonical(); ok {
+			return m, nil
+		}
+	}
+
+	start, end := p.numberSpan()
+	if start < 0 {
+		return defaultMoney(), p.fail(len(p.input), nil, "no amount")
+	}
+
+	prefix, err := p.affix(p.input[:start], 0, true)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	suffix, err := p.affix(p.input[end:], end, false)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	c, err := p.currency(prefix, suffix)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	v, err := p.number(p.input[start:end], start, c)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	negative, err := p.sign(prefix, suffix)
+	if err != nil {
+		return defaultMoney(), err
+	}
+
+	if p.opts.Strict {
+		if err := p.strictAffixes(prefix, suffix, c, negative); err != nil {
+			return defaultMoney(), err
+		}
+	}
+
+	if negative {
+		v = v.Neg()
+	}
+
+	return Money{
+		value:    v,
+		currency: c,
+		unit:     MAJOR,
+	}.packed(), nil
+}
+
+// reads the form String writes, e.g. "1.005 EUR" or "-1234.567 USD", in which . is always the decimal point
+// the code must be written exactly as String writes it, anything else is left to the lenient rules
+func (p parser) canonical() (Money, bool) {
+	number, code, ok := cut(p.input, " ")
+	if !ok {
+		return defaultMoney(), false
+	}
+
+	c, ok := p.reg.lookup(code)
+	if !ok || c.string() != code {
+		return defaultMoney(), false
+	}
+
+	integer, fraction, point := cut(strings.TrimPrefix(number, "-"), ".")
+	if !allDigits(integer) || (point && !allDigits(fraction)) {
+		return defaultMoney(), false
+	}
+
+	return Money{
+		value:    decimal.RequireFromString(number),
+		currency: c,
+		unit:     MAJOR,
+	}.packed(), true
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if !isDigit(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isSeparator(r rune) bool {
+	return r == '.' || r == ',' || r == '\'' || r == '’' || unicode.IsSpace(r)
+}
+
+// returns the byte offsets of the number, from the first digit to the last digit of the run of digits
+// and separators that follows it, or -1 if there are no digits
+func (p parser) numberSpan() (int, int) {
+	start := strings.IndexFunc(p.input, isDigit)
+	if start < 0 {
+		return -1, -1
+	}
+
+	end := start
+	for i, r := range p.input[start:] {
+		if isDigit(r) {
+			end = start + i + 1
+		} else if !isSeparator(r) {
+			break
+		}
+	}
+
+	return start, end
+}
+
+// an opening parenthesis can only come before the number and a closing one after it
+func (p parser) affix(text string, offset int, prefix bool) (parsedAffix, error) {
+	a := parsedAffix{
+		text:      text,
+		pos:       offset,
+		signPos:   -1,
+		markerPos: -1,
+	}
+
+	var marker strings.Builder
+	for i, r := range text {
+		pos := offset + i
+
+		switch {
+		case unicode.IsSpace(r):
+		case r == '-' || r == '−' || r == '+':
+			if a.sign != 0 {
+				return a, p.fail(pos, nil, "more than one sign")
+			}
+			a.sign = r
+			a.signPos = pos
+		case r == '(':
+			if !prefix || a.open {
+				return a, p.fail(pos, nil, "unexpected %q", r)
+			}
+			a.open = true
+		case r == ')':
+			if prefix || a.close {
+				return a, p.fail(pos, nil, "unexpected %q", r)
+			}
+			a.close = true
+		case isDigit(r):
+			return a, p.fail(pos, nil, "more than one amount")
+		default:
+			if a.markerPos < 0 {
+				a.markerPos = pos
+			}
+			marker.WriteRune(r)
+		}
+	}
+	a.marker = marker.String()
+
+	return a, nil
+}
+
+func (p parser) currency(prefix parsedAffix, suffix parsedAffix) (currency, error) {
+	if prefix.marker != "" && suffix.marker != "" {
+		return -1, p.fail(suffix.markerPos, nil, "currency given twice")
+	}
+
+	a := prefix
+	if suffix.marker != "" {
+		a = suffix
+	}
+
+	if a.marker == "" {
+		c, ok := p.reg.lookup(p.opts.Currency)
+		if !ok {
+			return -1, p.fail(len(p.input), ErrUnknownCurrency, "no currency given and no default currency")
+		}
+		return c, nil
+	}
+
+	if c, ok := p.code(a.marker); ok {
+		return c, nil
+	}
+
+	if c, ok := p.symbol(a.marker); ok {
+		return c, nil
+	}
+
+	return -1, p.fail(a.markerPos, ErrUnknownCurrency, "unknown currency %q", a.marker)
+}
+
+func (p parser) code(marker string) (currency, bool) {
+	if p.opts.Strict && marker != strings.ToUpper(marker) {
+		return -1, false
+	}
+
+	return p.reg.lookup(marker)
+}
+
+// matches a symbol against, in order, the default currency, the locale's symbols and the currency table
+func (p parser) symbol(marker string) (currency, bool) {
+	if c, ok := p.reg.lookup(p.opts.Currency); ok {
+		if p.symbolOf(c) == marker {
+			return c, true
+		}
+
+		if ci, _ := c.info(); !p.opts.Strict && narrowSymbol(ci.symbol) == marker {
+			return c, true
+		}
+	}
+
+	if p.loc != nil {
+		for _, code := range p.loc.symbolCodes {
+			if withoutSpace(p.loc.symbols[code]) == marker {
+				c, _ := p.reg.lookup(code)
+				return c, true
+			}
+		}
+	}
+
+	found := currency(-1)
+	for _, c := range p.reg.all() {
+		ci, _ := c.info()
+		if ci.symbol == "" || withoutSpace(ci.symbol) != marker {
+			continue
+		}
+		if p.loc != nil && p.loc.symbols[ci.code] != "" {
+			continue
+		}
+		if found >= 0 {
+			return -1, false
+		}
+		found = c
+	}
+
+	return found, found >= 0
+}
+
+// the currency's symbol in the locale, without spaces
+func (p parser) symbolOf(c currency) string {
+	if p.loc != nil {
+		return withoutSpace(p.loc.symbol(c))
+	}
+
+	ci, _ := c.info()
+	return withoutSpace(ci.symbol)
+}
+
+// e.g. $ for US$, ¥ for CN¥
+func narrowSymbol(s string) string {
+	return strings.TrimLeftFunc(s, unicode.IsLetter)
+}
+
+func withoutSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func (p parser) sign(prefix parsedAffix, suffix parsedAffix) (bool, error) {
+	if prefix.open != suffix.close {
+		if prefix.open {
+			return false, p.fail(len(p.input), nil, "missing ')'")
+		}
+		return false, p.fail(suffix.pos+strings.IndexRune(suffix.text, ')'), nil, "unexpected ')'")
+	}
+
+	if prefix.sign != 0 && suffix.sign != 0 {
+		return false, p.fail(suffix.signPos, nil, "more than one sign")
+	}
+
+	sign, pos := prefix.sign, prefix.signPos
+	if suffix.sign != 0 {
+		sign, pos = suffix.sign, suffix.signPos
+	}
+
+	if prefix.open && sign != 0 {
+		return false, p.fail(pos, nil, "sign inside parentheses")
+	}
+
+	return prefix.open || sign == '-' || sign == '−', nil
+}
+
+// separators of a number in the order they appear
+type separator struct {
+	r   rune
+	pos int
+}
+
+func (p parser) number(s string, offset int, c currency) (decimal.Decimal, error) {
+	var digits []byte
+	var seps []separator
+	for i, r := range s {
+		if isDigit(r) {
+			digits = append(digits, byte(r))
+			continue
+		}
+		seps = append(seps, separator{r: r, pos: offset + i})
+	}
+
+	decimalSep, err := p.decimalSeparator(s, offset, seps, c)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	// digits before each separator, so groups and the fraction can be measured
+	integer, fraction := s, ""
+	if decimalSep.pos >= 0 {
+		integer, fraction = s[:decimalSep.pos-offset], s[decimalSep.pos-offset+utf8.RuneLen(decimalSep.r):]
+	}
+
+	if err := p.grouping(integer, offset, seps, decimalSep); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	ci, _ := c.info()
+	if p.opts.Strict && len(fraction) != int(ci.exponent) {
+		pos := offset + len(s)
+		if decimalSep.pos >= 0 {
+			pos = decimalSep.pos
+		}
+		return decimal.Decimal{}, p.fail(pos, nil, "expected %d decimals for %s", ci.exponent, ci.code)
+	}
+
+	n := len(digits) - len(fraction)
+	if n == len(digits) {
+		return decimal.RequireFromString(string(digits)), nil
+	}
+
+	return decimal.RequireFromString(string(digits[:n]) + "." + string(digits[n:])), nil
+}
+
+func (p parser) decimalSeparator(s string, offset int, seps []separator, c currency) (separator, error) {
+	none := separator{pos: -1}
+
+	if p.loc != nil {
+		found := none
+		for _, sep := range seps {
+			if string(sep.r) != p.loc.decimal {
+				continue
+			}
+			if found.pos >= 0 {
+				return none, p.fail(sep.pos, nil, "more than one decimal separator")
+			}
+			found = sep
+		}
+
+		return found, p.checkAfterDecimal(seps, found)
+	}
+
+	var last separator
+	count := 0
+	for _, sep := range seps {
+		if sep.r == '.' || sep.r == ',' {
+			if count > 0 && sep.r != last.r {
+				// e.g. 1.234,56 or 1,234.56, the last of the two is the decimal separator
+				return sep, p.checkAfterDecimal(seps, sep)
+			}
+			last = sep
+			count++
+		}
+	}
+
+	if count != 1 {
+		return none, nil
+	}
+
+	following := 0
+	for _, r := range s[last.pos-offset+1:] {
+		if !isDigit(r) {
+			break
+		}
+		following++
+	}
+
+	// a leading 0 is never a group of its own, e.g. "0,125 EUR" is 0.125
+	ci, _ := c.info()
+	if following == 3 && ci.exponent < 3 && s[:last.pos-offset] != "0" {
+		return none, nil
+	}
+
+	return last, nil
+}
+
+// nothing but digits can follow the decimal separator
+func (p parser) checkAfterDecimal(seps []separator, decimalSep separator) error {
+	if decimalSep.pos < 0 {
+		return nil
+	}
+
+	for _, sep := range seps {
+		if sep.pos > decimalSep.pos {
+			return p.fail(sep.pos, nil, "unexpected %q after the decimal separator", sep.r)
+		}
+	}
+
+	return nil
+}
+
+// checks group separators and, given a locale, the size of each group
+func (p parser) grouping(integer string, offset int, seps []separator, decimalSep separator) error {
+	var groupSeps []separator
+	for _, sep := range seps {
+		if decimalSep.pos >= 0 && sep.pos >= decimalSep.pos {
+			break
+		}
+		if !p.isGroupSeparator(sep.r) {
+			return p.fail(sep.pos, nil, "unexpected %q in amount", sep.r)
+		}
+		groupSeps = append(groupSeps, sep)
+	}
+
+	if len(groupSeps) == 0 {
+		if p.opts.Strict && p.needsGrouping(integer) {
+			return p.fail(offset, nil, "expected group separators")
+		}
+		return nil
+	}
+
+	for _, sep := range groupSeps[1:] {
+		if !sameSeparator(sep.r, groupSeps[0].r) {
+			return p.fail(sep.pos, nil, "inconsistent group separators")
+		}
+	}
+
+	primary, secondary := 3, 3
+	if p.loc != nil {
+		primary, secondary = p.loc.standard.primary, p.loc.standard.secondary
+		if primary == 0 {
+			return p.fail(groupSeps[0].pos, nil, "unexpected group separator")
+		}
+	} else if n := len(groupSeps); n > 1 && groupSeps[n-1].pos-groupSeps[n-2].pos-utf8.RuneLen(groupSeps[n-2].r) == 2 {
+		// without a locale both 1,234,567 and 1,23,45,678 are accepted
+		secondary = 2
+	}
+
+	// sizes of the groups from the right, the leftmost may be short
+	ends := append(groupSeps, separator{pos: offset + len(integer)})
+	for i := len(ends) - 1; i > 0; i-- {
+		size := ends[i].pos - ends[i-1].pos - utf8.RuneLen(ends[i-1].r)
+		expected := secondary
+		if i == len(ends)-1 {
+			expected = primary
+		}
+		if size != expected {
+			return p.fail(ends[i-1].pos, nil, "expected %d digits after group separator", expected)
+		}
+	}
+
+	if first := groupSeps[0].pos - offset; first < 1 || first > secondary {
+		return p.fail(groupSeps[0].pos, nil, "unexpected group separator")
+	}
+
+	return nil
+}
+
+// whether the locale groups an integer part this long
+func (p parser) needsGrouping(integer string) bool {
+	digits := 0
+	for _, r := range integer {
+		if isDigit(r) {
+			digits++
+		}
+	}
+
+	primary := p.loc.standard.primary
+	return primary > 0 && digits >= primary+p.loc.minimumGrouping
+}
+
+// any kind of space is the same separator, as are both apostrophes
+func sameSeparator(a rune, b rune) bool {
+	apostrophe := func(r rune) bool { return r == '\'' || r == '’' }
+
+	return a == b || (unicode.IsSpace(a) && unicode.IsSpace(b)) || (apostrophe(a) && apostrophe(b))
+}
+
+func (p parser) isGroupSeparator(r rune) bool {
+	if p.loc == nil {
+		return r != utf8.RuneError
+	}
+
+	if string(r) == p.loc.group {
+		return true
+	}
+
+	if p.opts.Strict {
+		return false
+	}
+
+	return unicode.IsSpace(r) || r == '\'' || r == '’'
+}
+
+// the text around the number must be what the locale's patterns produce, with the symbol or code
+func (p parser) strictAffixes(prefix parsedAffix, suffix parsedAffix, c currency, negative bool) error {
+	patterns := []affixes{p.loc.standard.positive, p.loc.accounting.positive}
+	if negative {
+		patterns = []affixes{p.loc.standard.negative, p.loc.accounting.negative}
+	}
+
+	pos := 0
+	for _, a := range patterns {
+		for _, s := range []string{p.loc.symbol(c), c.string()} {
+			if !sameSpacing(withSymbol(a.prefix, s, true), prefix.text) {
+				continue
+			}
+			if sameSpacing(withSymbol(a.suffix, s, false), suffix.text) {
+				return nil
+			}
+			pos = suffix.pos
+		}
+	}
+
+	return p.fail(pos, nil, "not in the format of %s", p.opts.Locale)
+}
+
+// compares treating every kind of space as the same
+func sameSpacing(a string, b string) bool {
+	space := func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}
+
+	return strings.Map(space, a) == strings.Map(space, b)
+}
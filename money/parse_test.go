@@ -0,0 +1,214 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	cases := []struct {
+		s    string
+		opts ParseOptions
+		e    Money
+	}{
+		{"€1.234,50", ParseOptions{Locale: "de-DE"}, NewEuro(123450, -2)},
+		{"€1.234,50", ParseOptions{}, NewEuro(123450, -2)},
+		{"USD 12.00", ParseOptions{}, New(1200, -2, "USD", "dollar")},
+		{"usd 12", ParseOptions{}, New(12, 0, "USD", "dollar")},
+		{"-$3.5", ParseOptions{Locale: "en-US"}, New(-35, -1, "USD", "dollar")},
+		{"-$3.5", ParseOptions{Currency: "USD"}, New(-35, -1, "USD", "dollar")},
+		{"$-3.5", ParseOptions{Currency: "USD"}, New(-35, -1, "USD", "dollar")},
+		{"12,50 EUR", ParseOptions{}, NewEuro(1250, -2)},
+		{"12,50 EUR", ParseOptions{Locale: "fr-FR"}, NewEuro(1250, -2)},
+		{"12.50", ParseOptions{Currency: "EUR"}, NewEuro(1250, -2)},
+		{"(12.50 EUR)", ParseOptions{}, NewEuro(-1250, -2)},
+		{"1 234,56 CHF", ParseOptions{Locale: "fr-CH"}, New(123456, -2, "CHF", "franc")},
+		{"CHF 1'234.56", ParseOptions{Locale: "de-CH"}, New(123456, -2, "CHF", "franc")},
+		{"1,234 USD", ParseOptions{}, New(1234, 0, "USD", "dollar")},
+		{"1,234 KWD", ParseOptions{}, New(1234, -3, "KWD", "major")},
+		{"1,234,567.891 USD", ParseOptions{}, New(1234567891, -3, "USD", "dollar")},
+		{"£5", ParseOptions{}, New(5, 0, "GBP", "pound")},
+		{"12,5 kr", ParseOptions{Locale: "sv-SE"}, New(125, -1, "SEK", "major")},
+		{"1.234.567 EUR", ParseOptions{}, NewEuro(1234567, 0)},
+		{"0,125 USD", ParseOptions{}, New(125, -3, "USD", "dollar")},
+		{"-0.005 EUR", ParseOptions{}, NewEuro(-5, -3)},
+		{"1,23,45,678.90 INR", ParseOptions{}, New(1234567890, -2, "INR", "major")},
+	}
+
+	for _, c := range cases {
+		r, err := Parse(c.s, c.opts)
+		if err != nil {
+			t.Fatalf("did not expect an error parsing %q, got %v", c.s, err)
+		}
+
+		if !r.exactEqual(c.e) {
+			t.Fatalf("expected %q to parse as %s but got %s", c.s, c.e.string(), r.string())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		s    string
+		opts ParseOptions
+		pos  int
+	}{
+		{"EUR", ParseOptions{}, 3},
+		{"12.50", ParseOptions{}, 5},
+		{"12.50 XYZ", ParseOptions{}, 6},
+		{"EUR 12.50 EUR", ParseOptions{}, 10},
+		{"--12.50 EUR", ParseOptions{}, 1},
+		{"(12.50 EUR", ParseOptions{}, 10},
+		{"12.50) EUR", ParseOptions{}, 5},
+		{"12,50 USD", ParseOptions{Locale: "en-US"}, 2},
+		{"1.234.56 EUR", ParseOptions{Locale: "de-DE"}, 5},
+		{"1,2.5 EUR", ParseOptions{Locale: "de-DE"}, 3},
+		{"12.50 EUR 3", ParseOptions{}, 10},
+		{"$3.50", ParseOptions{}, 0},
+		{"1.5 kr", ParseOptions{Locale: "sv-SE"}, 1},
+		{"12.5.5 EUR", ParseOptions{}, 4},
+		{"1 23 EUR", ParseOptions{}, 1},
+		{"1.234 567 EUR", ParseOptions{}, 5},
+		{"1 234'567 CHF", ParseOptions{Locale: "de-CH"}, 5},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.s, c.opts)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("expected a *ParseError parsing %q but got %v", c.s, err)
+		}
+
+		if pe.Pos != c.pos {
+			t.Fatalf("expected %q to fail at %d but got %d: %v", c.s, c.pos, pe.Pos, err)
+		}
+	}
+
+	if _, err := Parse("12.50 XYZ", ParseOptions{}); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency but got %v", err)
+	}
+
+	if _, err := Parse("12.50 EUR", ParseOptions{Locale: "xx-XX"}); !errors.Is(err, ErrUnknownLocale) {
+		t.Fatalf("expected ErrUnknownLocale but got %v", err)
+	}
+
+	if _, err := Parse("12.50 EUR", ParseOptions{Strict: true}); err == nil {
+		t.Fatalf("expected strict parsing without a locale to fail")
+	}
+}
+
+func TestParseStringRoundTrip(t *testing.T) {
+	ms := []Money{
+		NewEuro(1234, -2),
+		NewEuro(1005, -3),
+		NewEuro(-5, -3),
+		NewEuroCent(15, -1),
+		New(1234567, -3, "USD", "dollar"),
+		New(1234, 0, "USD", "dollar"),
+		New(500, 0, "JPY", "yen"),
+		New(1234567, -4, "KWD", "major"),
+		New(123456789012345, -2, "EUR", "cent"),
+	}
+
+	for _, m := range ms {
+		r, err := Parse(m.String(), ParseOptions{})
+		if err != nil {
+			t.Fatalf("did not expect an error parsing %q, got %v", m.String(), err)
+		}
+		if !r.Equal(m) {
+			t.Fatalf("expected %q to read back as %s, got %s", m.String(), m.string(), r.string())
+		}
+	}
+}
+
+func TestLocaleSymbolOrder(t *testing.T) {
+	for name, l := range locales {
+		if len(l.symbolCodes) != len(l.symbols) {
+			t.Fatalf("%s: expected %d symbol codes, got %d", name, len(l.symbols), len(l.symbolCodes))
+		}
+
+		for i := 1; i < len(l.symbolCodes); i++ {
+			prev, code := l.symbolCodes[i-1], l.symbolCodes[i]
+			if len(l.symbols[prev]) < len(l.symbols[code]) || (len(l.symbols[prev]) == len(l.symbols[code]) && prev > code) {
+				t.Fatalf("%s: %s is matched before %s", name, prev, code)
+			}
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	ok := []struct {
+		s      string
+		locale string
+		e      Money
+	}{
+		{"$1,234.56", "en-US", New(123456, -2, "USD", "dollar")},
+		{"($1,234.56)", "en-US", New(-123456, -2, "USD", "dollar")},
+		{"USD 1,234.56", "en-US", New(123456, -2, "USD", "dollar")},
+		{"1.234,56 €", "de-DE", NewEuro(123456, -2)},
+		{"1.234,56 EUR", "de-DE", NewEuro(123456, -2)},
+	}
+
+	for _, c := range ok {
+		r, err := Parse(c.s, ParseOptions{Locale: c.locale, Strict: true})
+		if err != nil {
+			t.Fatalf("did not expect an error parsing %q, got %v", c.s, err)
+		}
+
+		if !r.exactEqual(c.e) {
+			t.Fatalf("expected %q to parse as %s but got %s", c.s, c.e.string(), r.string())
+		}
+	}
+
+	bad := []struct {
+		s      string
+		locale string
+	}{
+		{"$1234.56", "en-US"},
+		{"$1,234.5", "en-US"},
+		{"usd 1,234.56", "en-US"},
+		{"1,234.56$", "en-US"},
+		{"1 234,56 €", "de-DE"},
+		{"+1.234,56 €", "de-DE"},
+	}
+
+	for _, c := range bad {
+		if _, err := Parse(c.s, ParseOptions{Locale: c.locale, Strict: true}); err == nil {
+			t.Fatalf("expected %q to fail strict parsing in %s", c.s, c.locale)
+		}
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	ms := []Money{
+		NewEuro(123456789, -2),
+		NewEuro(-5, -2),
+		New(1234, 0, "JPY", "yen"),
+		New(-987654321, -3, "KWD", "major"),
+		New(100, -2, "CHF", "franc"),
+		New(-123456, -2, "USD", "dollar"),
+		New(123456, -2, "SEK", "major"),
+		New(1234567890, -2, "INR", "major"),
+	}
+
+	for locale := range locales {
+		for _, m := range ms {
+			for _, format := range []func(Money, string) (string, error){Money.FormatLocale, Money.FormatAccounting} {
+				s, err := format(m, locale)
+				if err != nil {
+					t.Fatalf("did not expect an error formatting %s, got %v", m.string(), err)
+				}
+
+				r, err := Parse(s, ParseOptions{Locale: locale, Currency: m.Currency(), Strict: true})
+				if err != nil {
+					t.Fatalf("did not expect an error parsing %q in %s, got %v", s, locale, err)
+				}
+
+				if !r.Equal(m) {
+					t.Fatalf("expected %q in %s to parse as %s but got %s", s, locale, m.string(), r.string())
+				}
+			}
+		}
+	}
+}
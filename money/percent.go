@@ -0,0 +1,132 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidPercent = errors.New("invalid percent")
+
+// decimal places between a fraction and percentage points, and a fraction and basis points
+// scaling by shifting keeps every digit, where dividing would round to decimal.DivisionPrecision
+const (
+	pointsShift      = 2
+	basisPointsShift = 4
+)
+
+// an exact percentage, held as a fraction so 12.5% is 0.125
+// the zero value is 0%
+type Percent struct {
+	fraction decimal.Decimal
+}
+
+// creates a percentage from percentage points, e.g. 12.5 for 12.5%
+func NewPercent(d decimal.Decimal) Percent {
+	return Percent{fraction: d.Shift(-pointsShift)}
+}
+
+// creates a percentage from a fraction, e.g. 0.125 for 12.5%
+func NewPercentFromFraction(d decimal.Decimal) Percent {
+	return Percent{fraction: d}
+}
+
+// creates a percentage from basis points, e.g. 1250 for 12.5%
+func NewPercentFromBasisPoints(bp int64) Percent {
+	return Percent{fraction: decimal.New(bp, -basisPointsShift)}
+}
+
+// parses "12.5%", "12.5", "1250bp", "1250 bps" or "1250‱", a bare number being percentage points
+func ParsePercent(s string) (Percent, error) {
+	t := strings.TrimSpace(s)
+	shift := int32(pointsShift)
+
+	switch {
+	case strings.HasSuffix(t, "%"):
+		t = strings.TrimSuffix(t, "%")
+	case strings.HasSuffix(t, "‱"):
+		t, shift = strings.TrimSuffix(t, "‱"), basisPointsShift
+	case strings.HasSuffix(strings.ToLower(t), "bps"):
+		t, shift = t[:len(t)-3], basisPointsShift
+	case strings.HasSuffix(strings.ToLower(t), "bp"):
+		t, shift = t[:len(t)-2], basisPointsShift
+	}
+
+	d, err := decimal.NewFromString(strings.TrimSpace(t))
+	if err != nil || strings.ContainsAny(t, "eE") {
+		return Percent{}, fmt.Errorf("money: parse percent %q: %w", s, ErrInvalidPercent)
+	}
+
+	return Percent{fraction: d.Shift(-shift)}, nil
+}
+
+// returns the percentage as a fraction, e.g. 0.125 for 12.5%
+func (p Percent) Fraction() decimal.Decimal {
+	return p.fraction
+}
+
+// returns the percentage points, e.g. 12.5 for 12.5%
+func (p Percent) Points() decimal.Decimal {
+	return p.fraction.Shift(pointsShift)
+}
+
+// returns the basis points, e.g. 1250 for 12.5%
+func (p Percent) BasisPoints() decimal.Decimal {
+	return p.fraction.Shift(basisPointsShift)
+}
+
+func (p Percent) IsZero() bool {
+	return p.fraction.IsZero()
+}
+
+func (p Percent) Equal(q Percent) bool {
+	return p.fraction.Equal(q.fraction)
+}
+
+// returns e.g. "12.5%"
+func (p Percent) String() string {
+	return p.Points().String() + "%"
+}
+
+func (p Percent) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Percent) UnmarshalText(text []byte) error {
+	r, err := ParsePercent(string(text))
+	if err != nil {
+		return err
+	}
+	*p = r
+
+	return nil
+}
+
+// returns p of m, e.g. 10% of 50 EUR is 5 EUR
+func (m Money) ApplyPercent(p Percent) Money {
+	return m.withDec(m.dec().Mul(p.fraction)).autoRound()
+}
+
+// returns m less p of it, e.g. 50 EUR less 10% is 45 EUR
+func (m Money) Discount(p Percent) Money {
+	v := m.dec()
+	return m.withDec(v.Sub(v.Mul(p.fraction))).autoRound()
+}
+
+// returns m plus p of it, e.g. 50 EUR marked up 10% is 55 EUR
+func (m Money) Markup(p Percent) Money {
+	v := m.dec()
+	return m.withDec(v.Add(v.Mul(p.fraction))).autoRound()
+}
+
+// returns the percentage m1 is of m2, e.g. 5 EUR is 10% of 50 EUR
+// m2 is converted to the unit of m1 when they differ
+func (m1 Money) PercentOf(m2 Money) (Percent, error) {
+	if err := m1.checkDivisor("percent of", m2); err != nil {
+		return Percent{}, err
+	}
+
+	return Percent{fraction: m1.dec().Div(m2.in(m1.unit).dec())}, nil
+}
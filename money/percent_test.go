@@ -0,0 +1,118 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParsePercent(t *testing.T) {
+	cases := []struct {
+		in       string
+		fraction string
+	}{
+		{"12.5%", "0.125"},
+		{" 12.5 % ", "0.125"},
+		{"12.5", "0.125"},
+		{"-3%", "-0.03"},
+		{"1250bp", "0.125"},
+		{"1250 bps", "0.125"},
+		{"1 BP", "0.0001"},
+		{"25‱", "0.0025"},
+		{"0.1%", "0.001"},
+		{"0.00000000000000123%", "0.0000000000000000123"},
+		{"0.000000000000000001bp", "0.0000000000000000000001"},
+	}
+
+	for _, c := range cases {
+		p, err := ParsePercent(c.in)
+		if err != nil {
+			t.Fatalf("%q: %v", c.in, err)
+		}
+		if !p.Fraction().Equal(decimal.RequireFromString(c.fraction)) {
+			t.Fatalf("%q: expected %s, got %s", c.in, c.fraction, p.Fraction())
+		}
+	}
+
+	for _, in := range []string{"", "%", "abc%", "1e2%", "12,5%", "bp"} {
+		if _, err := ParsePercent(in); !errors.Is(err, ErrInvalidPercent) {
+			t.Fatalf("%q: expected ErrInvalidPercent, got %v", in, err)
+		}
+	}
+}
+
+func TestPercentConstructors(t *testing.T) {
+	p := NewPercent(decimal.RequireFromString("12.5"))
+	if !p.Equal(NewPercentFromFraction(decimal.RequireFromString("0.125"))) || !p.Equal(NewPercentFromBasisPoints(1250)) {
+		t.Fatalf("expected the constructors to agree")
+	}
+	if p.String() != "12.5%" || !p.Points().Equal(decimal.RequireFromString("12.5")) || !p.BasisPoints().Equal(decimal.NewFromInt(1250)) {
+		t.Fatalf("unexpected %s, %s points, %s bp", p, p.Points(), p.BasisPoints())
+	}
+
+	tiny := NewPercent(decimal.RequireFromString("0.00000000000000123"))
+	if !tiny.Fraction().Equal(decimal.RequireFromString("0.0000000000000000123")) {
+		t.Fatalf("expected an exact fraction, got %s", tiny.Fraction())
+	}
+
+	var q Percent
+	if !q.IsZero() || q.String() != "0%" {
+		t.Fatalf("expected the zero value to be 0%%, got %s", q)
+	}
+}
+
+func TestPercentJSON(t *testing.T) {
+	var v struct {
+		Rate Percent `json:"rate"`
+	}
+	if err := json.Unmarshal([]byte(`{"rate":"19%"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Rate.Equal(NewPercentFromBasisPoints(1900)) {
+		t.Fatalf("unexpected rate %s", v.Rate)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"rate":"19%"}` {
+		t.Fatalf("unexpected json %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"rate":"lots"}`), &v); !errors.Is(err, ErrInvalidPercent) {
+		t.Fatalf("expected ErrInvalidPercent, got %v", err)
+	}
+}
+
+func TestApplyPercent(t *testing.T) {
+	ten := NewPercentFromBasisPoints(1000)
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(5, 0), NewEuro(50, 0).ApplyPercent(ten))
+	moneyTest{t}.assertMoneyEqual(NewEuro(45, 0), NewEuro(50, 0).Discount(ten))
+	moneyTest{t}.assertMoneyEqual(NewEuro(55, 0), NewEuro(50, 0).Markup(ten))
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(500, 0), NewEuroCent(5000, 0).ApplyPercent(ten))
+
+	// exact where a float factor would not be, 0.1 * 3 is not 0.3 in binary
+	p, _ := ParsePercent("30%")
+	moneyTest{t}.assertMoneyEqual(NewEuro(3, -1), NewEuro(1, 0).ApplyPercent(p))
+}
+
+func TestPercentOf(t *testing.T) {
+	p, err := NewEuro(5, 0).PercentOf(NewEuroCent(5000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(NewPercentFromBasisPoints(1000)) {
+		t.Fatalf("expected 10%%, got %s", p)
+	}
+
+	if _, err := NewEuro(5, 0).PercentOf(ZeroEuro()); !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("expected ErrDivisionByZero, got %v", err)
+	}
+	if _, err := NewEuro(5, 0).PercentOf(ZeroUsDollar()); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package money
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// e.g. "12.34 EUR", always in the major unit and with at least the currency's minor unit places
+// see Format for the other verbs fmt accepts
+func (m Money) String() string {
+	if !m.valid() {
+		return "invalid money"
+	}
+
+	return m.majorString() + " " + m.currency.string()
+}
+
+// the value in the major unit, padded to the currency's exponent but keeping any finer precision
+func (m Money) majorString() string {
+	v := m.ToMajor().dec()
+	if exp := m.Exponent(); -v.Exponent() <= exp {
+		return v.StringFixed(exp)
+	}
+
+	return v.String()
+}
+
+// implements fmt.Formatter
+//
+//	%v, %s  12.34 EUR, as String
+//	%q      "12.34 EUR"
+//	%+v     the value in its own unit with the unit name, e.g. 1234 EUR (CENT)
+//	%d      the number of minor units, rounded half even, e.g. 1234
+//	%f, %F  the value in the major unit, rounded half even to the precision or else the currency's exponent, e.g. 12.34
+//
+// width and the '-' flag pad every verb, '+', ' ' and '0' apply to %d and %f as they do for numbers
+func (m Money) Format(f fmt.State, verb rune) {
+	if !m.valid() {
+		writePadded(f, "invalid money")
+		return
+	}
+
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			writePadded(f, m.string())
+			return
+		}
+		writePadded(f, m.String())
+	case 's':
+		writePadded(f, m.String())
+	case 'q':
+		writePadded(f, fmt.Sprintf("%q", m.String()))
+	case 'd':
+		writeNumber(f, m.ToMinor().dec().RoundBank(0).String())
+	case 'f', 'F':
+		places := m.Exponent()
+		if p, ok := f.Precision(); ok {
+			places = int32(p)
+		}
+		writeNumber(f, m.ToMajor().dec().RoundBank(places).StringFixed(places))
+	default:
+		fmt.Fprintf(f, "%%!%c(money.Money=%s)", verb, m.String())
+	}
+}
+
+// writes s padded to the width, on the right with the '-' flag and otherwise on the left
+func writePadded(f fmt.State, s string) {
+	w, ok := f.Width()
+	if !ok || len([]rune(s)) >= w {
+		fmt.Fprint(f, s)
+		return
+	}
+
+	pad := strings.Repeat(" ", w-len([]rune(s)))
+	if f.Flag('-') {
+		fmt.Fprint(f, s+pad)
+		return
+	}
+	fmt.Fprint(f, pad+s)
+}
+
+// writes a decimal number, signed with the '+' or ' ' flag and zero padded after the sign with the '0' flag
+func writeNumber(f fmt.State, s string) {
+	sign := ""
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = "-", s[1:]
+	case f.Flag('+'):
+		sign = "+"
+	case f.Flag(' '):
+		sign = " "
+	}
+
+	if w, ok := f.Width(); ok && f.Flag('0') && !f.Flag('-') && len(sign)+len(s) < w {
+		s = strings.Repeat("0", w-len(sign)-len(s)) + s
+	}
+
+	writePadded(f, sign+s)
+}
+
+// implements slog.LogValuer, logging the value in its own unit with the currency and unit as in JSON
+// e.g. amount.value=12.34 amount.currency=EUR amount.unit=EURO
+func (m Money) LogValue() slog.Value {
+	if !m.valid() {
+		return slog.StringValue("invalid money")
+	}
+
+	return slog.GroupValue(
+		slog.String("value", m.dec().String()),
+		slog.String("currency", m.currency.string()),
+		slog.String("unit", m.unit.string(m.currency)),
+	)
+}
@@ -0,0 +1,93 @@
+package money
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		m Money
+		e string
+	}{
+		{NewEuro(1234, -2), "12.34 EUR"},
+		{NewEuro(123, -1), "12.30 EUR"},
+		{NewEuroCent(1234, 0), "12.34 EUR"},
+		{NewEuro(-5, -3), "-0.005 EUR"},
+		{New(1234, 0, "JPY", "yen"), "1234 JPY"},
+		{New(1234567, -3, "KWD", "major"), "1234.567 KWD"},
+		{defaultMoney(), "invalid money"},
+	}
+
+	for _, c := range cases {
+		if r := c.m.String(); r != c.e {
+			t.Fatalf("expected %q, got %q", c.e, r)
+		}
+	}
+
+	if r := fmt.Sprint(NewEuro(1234, -2)); r != "12.34 EUR" {
+		t.Fatalf("expected fmt to use String, got %q", r)
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	m := NewEuroCent(1234, 0)
+	cases := []struct {
+		format string
+		m      Money
+		e      string
+	}{
+		{"%v", m, "12.34 EUR"},
+		{"%s", m, "12.34 EUR"},
+		{"%q", m, `"12.34 EUR"`},
+		{"%+v", m, "1234 EUR (CENT)"},
+		{"%+v", NewEuro(1234, -2), "12.34 EUR (EURO)"},
+		{"%d", m, "1234"},
+		{"%d", NewEuro(1234, -2), "1234"},
+		{"%d", NewEuroCent(125, -1), "12"},
+		{"%d", NewEuroCent(135, -1), "14"},
+		{"%f", m, "12.34"},
+		{"%.2f", NewEuro(12345, -3), "12.34"},
+		{"%.1f", m, "12.3"},
+		{"%.0f", m, "12"},
+		{"%.3f", m, "12.340"},
+		{"%f", New(1234, 0, "JPY", "yen"), "1234"},
+		{"%+d", m, "+1234"},
+		{"% d", m, " 1234"},
+		{"%08.2f", NewEuro(-1234, -2), "-0012.34"},
+		{"%8d", m, "    1234"},
+		{"%-8d|", m, "1234    |"},
+		{"%12s|", m, "   12.34 EUR|"},
+		{"%-12v|", m, "12.34 EUR   |"},
+		{"%x", m, "%!x(money.Money=12.34 EUR)"},
+		{"%v", defaultMoney(), "invalid money"},
+		{"%d", defaultMoney(), "invalid money"},
+	}
+
+	for _, c := range cases {
+		if r := fmt.Sprintf(c.format, c.m); r != c.e {
+			t.Fatalf("%s: expected %q, got %q", c.format, c.e, r)
+		}
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	logger.Info("paid", "amount", NewEuroCent(1234, 0), "fee", defaultMoney())
+
+	e := `{"level":"INFO","msg":"paid","amount":{"value":"1234","currency":"EUR","unit":"CENT"},"fee":"invalid money"}` + "\n"
+	if b.String() != e {
+		t.Fatalf("expected %s, got %s", e, b.String())
+	}
+}
@@ -0,0 +1,223 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrCurrencyConflict = errors.New("currency conflict")
+
+// the largest exponent a registered currency may have, e.g. 18 for wei in ETH
+const maxExponent = 18
+
+// a currency added at runtime, e.g. a cryptocurrency or loyalty points
+type CurrencyDefinition struct {
+	// 2 to 10 letters or digits, upper cased on registration, e.g. "BTC" or "USDT"
+	Code string
+	// decimal places of the minor unit, 0 to 18, e.g. 8 for BTC
+	Exponent int32
+	Symbol   string
+	Name     string
+	// optional names of the units, e.g. "BITCOIN" and "SATOSHI"
+	Major string
+	Minor string
+}
+
+// a set of currencies, the ISO 4217 table followed by those registered at runtime
+// a registry decides which codes are known when parsing, while money keeps the currency it was created in
+// whichever registry is in use, e.g. BTC money created under one registry is still BTC under another
+// the package wide functions use the registry set by UseRegistry, Registry.New, ParseOptions.Registry and
+// JSONCodec.Registry use a given one without touching it, e.g. for tests that run in parallel
+// the zero value holds only the ISO 4217 currencies
+type Registry struct {
+	mu sync.Mutex
+	// the current *registryTable, replaced whole on every registration so lookups do not lock
+	table atomic.Value
+}
+
+type registryTable struct {
+	// the registered currencies visible in the registry, in the order they were registered
+	custom []currency
+	codes  map[string]currency
+}
+
+var isoTable = &registryTable{codes: currencyCodes}
+
+// returns a registry holding only the ISO 4217 currencies
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// every currency registered in any registry, after the ISO 4217 table
+// it is only ever appended to, so a currency index means the same for the life of the process
+var registered struct {
+	mu sync.Mutex
+	// the current []currencyInfo
+	infos atomic.Value
+}
+
+func registeredInfos() []currencyInfo {
+	infos, _ := registered.infos.Load().([]currencyInfo)
+	return infos
+}
+
+// returns the currency for ci, reusing that of an identical earlier registration in any registry
+func register(ci currencyInfo) currency {
+	registered.mu.Lock()
+	defer registered.mu.Unlock()
+
+	infos := registeredInfos()
+	for i, r := range infos {
+		if r == ci {
+			return currency(len(currencies) + i)
+		}
+	}
+
+	// readers only see the entries within the length they loaded, so appending in place is safe
+	registered.infos.Store(append(infos, ci))
+
+	return currency(len(currencies) + len(infos))
+}
+
+var (
+	defaultRegistry = NewRegistry()
+	activeRegistry  atomic.Value
+)
+
+func init() {
+	activeRegistry.Store(defaultRegistry)
+}
+
+func registry() *Registry {
+	return activeRegistry.Load().(*Registry)
+}
+
+// returns r, or the registry in use when r is nil
+func registryOr(r *Registry) *Registry {
+	if r == nil {
+		return registry()
+	}
+
+	return r
+}
+
+// makes r the registry used by the whole package until restore is called, e.g. in a test
+// it changes which codes parse for every goroutine, so tests that swap registries must not run in parallel,
+// money already created keeps its currency either way
+//
+//	defer money.UseRegistry(money.NewRegistry())()
+func UseRegistry(r *Registry) (restore func()) {
+	prev := activeRegistry.Swap(r)
+
+	return func() {
+		activeRegistry.Store(prev)
+	}
+}
+
+// adds a currency to the registry in use, see Registry.Register
+func RegisterCurrency(d CurrencyDefinition) error {
+	return registry().Register(d)
+}
+
+// adds a currency to the registry
+// registering a code again with the same definition does nothing, while an ISO code or a code
+// registered with a different definition is an ErrCurrencyConflict
+func (r *Registry) Register(d CurrencyDefinition) error {
+	ci := currencyInfo{
+		code:     strings.ToUpper(d.Code),
+		exponent: d.Exponent,
+		name:     d.Name,
+		major:    strings.ToUpper(d.Major),
+		minor:    strings.ToUpper(d.Minor),
+		symbol:   d.Symbol,
+	}
+
+	if !validCode(ci.code) {
+		return fmt.Errorf("money: register currency %q: code must be 2 to 10 letters or digits", d.Code)
+	}
+	if ci.exponent < 0 || ci.exponent > maxExponent {
+		return fmt.Errorf("money: register currency %s: exponent %d outside 0 to %d", ci.code, ci.exponent, maxExponent)
+	}
+	if _, ok := currencyCodes[ci.code]; ok {
+		return fmt.Errorf("money: register currency %s: an ISO 4217 code: %w", ci.code, ErrCurrencyConflict)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.current()
+	if c, ok := t.codes[ci.code]; ok {
+		if existing, _ := c.info(); existing == ci {
+			return nil
+		}
+		return fmt.Errorf("money: register currency %s: registered with a different definition: %w", ci.code, ErrCurrencyConflict)
+	}
+
+	c := register(ci)
+
+	codes := make(map[string]currency, len(t.codes)+1)
+	for code, existing := range t.codes {
+		codes[code] = existing
+	}
+	codes[ci.code] = c
+
+	custom := make([]currency, len(t.custom), len(t.custom)+1)
+	copy(custom, t.custom)
+
+	r.table.Store(&registryTable{custom: append(custom, c), codes: codes})
+
+	return nil
+}
+
+// creates val * 10^exp as New does, resolving the code in r rather than the registry in use
+func (r *Registry) New(val int64, exp int32, c string, u string) Money {
+	return r.new(decimal.New(val, exp), c, u)
+}
+
+// resolves an ISO 4217 code, or one registered with r, in any case
+func (r *Registry) lookup(s string) (c currency, ok bool) {
+	c, ok = r.current().codes[strings.ToUpper(s)]
+	if !ok {
+		c = -1
+	}
+
+	return
+}
+
+func (r *Registry) current() *registryTable {
+	if t, ok := r.table.Load().(*registryTable); ok {
+		return t
+	}
+
+	return isoTable
+}
+
+func validCode(code string) bool {
+	if len(code) < 2 || len(code) > 10 {
+		return false
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// returns every currency of the registry, ISO first
+func (r *Registry) all() []currency {
+	custom := r.current().custom
+
+	all := make([]currency, 0, len(currencies)+len(custom))
+	for i := range currencies {
+		all = append(all, currency(i))
+	}
+
+	return append(all, custom...)
+}
@@ -0,0 +1,228 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+var (
+	btc     = CurrencyDefinition{Code: "BTC", Exponent: 8, Symbol: "₿", Name: "Bitcoin", Major: "bitcoin", Minor: "satoshi"}
+	eth     = CurrencyDefinition{Code: "ETH", Exponent: 18, Symbol: "Ξ", Name: "Ether"}
+	usdt    = CurrencyDefinition{Code: "usdt", Exponent: 6, Name: "Tether"}
+	loyalty = CurrencyDefinition{Code: "PTS", Exponent: 0, Name: "Loyalty points"}
+)
+
+func TestRegisterCurrency(t *testing.T) {
+	defer UseRegistry(NewRegistry())()
+
+	for _, d := range []CurrencyDefinition{btc, eth, usdt, loyalty} {
+		if err := RegisterCurrency(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := New(1, 0, "btc", "SATOSHI")
+	if m.Currency() != "BTC" || m.Unit() != "SATOSHI" || m.Exponent() != 8 || m.CurrencyName() != "Bitcoin" {
+		t.Fatalf("unexpected %s", m.string())
+	}
+	moneyTest{t}.assertMoneyEqual(New(1, -8, "BTC", "BITCOIN"), m.ToMajor())
+
+	wei := New(1, 0, "ETH", "MINOR")
+	moneyTest{t}.assertMoneyEqual(New(1, -18, "ETH", "MAJOR"), wei.ToMajor())
+
+	if p := New(5, 0, "PTS", "MINOR"); p.Unit() != "MAJOR" {
+		t.Fatalf("expected points to only have a major unit, got %s", p.Unit())
+	}
+	if m := New(1, 0, "USDT", "MAJOR"); m.Currency() != "USDT" {
+		t.Fatalf("expected the code to be upper cased, got %q", m.Currency())
+	}
+
+	if _, ok := New(1, 0, "BTC", "MAJOR").Add(New(1, 0, "EUR", "MAJOR")); ok {
+		t.Fatalf("expected adding BTC to EUR to fail")
+	}
+}
+
+func TestRegisteredCurrencyCodecs(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	if err := r.Register(btc); err != nil {
+		t.Fatal(err)
+	}
+
+	var m Money
+	if err := (JSONCodec{Registry: r}).Unmarshal([]byte(`{"value":"0.5","currency":"BTC","unit":"BITCOIN"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(r.New(5, -1, "BTC", "MAJOR"), m)
+	if err := json.Unmarshal([]byte(`{"value":"0.5","currency":"BTC"}`), &m); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected BTC to be unknown to the default codec, got %v", err)
+	}
+
+	s, err := r.New(12345, -4, "BTC", "MAJOR").FormatLocale("en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "₿1.23450000" {
+		t.Fatalf("unexpected format %q", s)
+	}
+
+	p, err := Parse("₿1.5", ParseOptions{Registry: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(r.New(15, -1, "BTC", "MAJOR"), p)
+
+	p, err = Parse("2 BTC", ParseOptions{Registry: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moneyTest{t}.assertMoneyEqual(r.New(2, 0, "BTC", "MAJOR"), p)
+
+	if _, err := Parse("2 BTC", ParseOptions{}); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("expected BTC to be unknown without the registry, got %v", err)
+	}
+}
+
+func TestRegisterConflicts(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Register(btc); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(btc); err != nil {
+		t.Fatalf("expected registering the same definition again to succeed, got %v", err)
+	}
+
+	cases := []struct {
+		d   CurrencyDefinition
+		err error
+	}{
+		{CurrencyDefinition{Code: "EUR", Exponent: 2}, ErrCurrencyConflict},
+		{CurrencyDefinition{Code: "btc", Exponent: 2}, ErrCurrencyConflict},
+		{CurrencyDefinition{Code: "X", Exponent: 2}, nil},
+		{CurrencyDefinition{Code: "BT-C", Exponent: 2}, nil},
+		{CurrencyDefinition{Code: "BIG", Exponent: 19}, nil},
+		{CurrencyDefinition{Code: "NEG", Exponent: -1}, nil},
+	}
+
+	for _, c := range cases {
+		err := r.Register(c.d)
+		if err == nil {
+			t.Fatalf("%s: expected an error", c.d.Code)
+		}
+		if c.err != nil && !errors.Is(err, c.err) {
+			t.Fatalf("%s: expected %v, got %v", c.d.Code, c.err, err)
+		}
+	}
+}
+
+func TestRegistryIsolation(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(btc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := parseCurrency("BTC"); ok {
+		t.Fatalf("expected BTC to be unknown outside the registry")
+	}
+	if m := New(1, 0, "BTC", "MAJOR"); m.IsValid() {
+		t.Fatalf("expected BTC money to be invalid outside the registry, got %s", m)
+	}
+	if m := r.New(1, 0, "BTC", "MAJOR"); m.Currency() != "BTC" {
+		t.Fatalf("expected BTC money from the registry, got %s", m)
+	}
+
+	restore := UseRegistry(r)
+	if _, ok := parseCurrency("BTC"); !ok {
+		t.Fatalf("expected BTC to be known in the registry")
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuro(1, 0), New(1, 0, "EUR", "EURO"))
+	restore()
+
+	if _, ok := parseCurrency("BTC"); ok {
+		t.Fatalf("expected BTC to be unknown once restored")
+	}
+}
+
+func TestRegisteredCurrencyKeepsItsIdentity(t *testing.T) {
+	restore := UseRegistry(NewRegistry())
+	if err := RegisterCurrency(btc); err != nil {
+		t.Fatal(err)
+	}
+	m := New(1, 0, "BTC", "MAJOR")
+	restore()
+
+	defer UseRegistry(NewRegistry())()
+	if err := RegisterCurrency(loyalty); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Currency() != "BTC" || m.Exponent() != 8 || m.string() != "1 BTC (BITCOIN)" {
+		t.Fatalf("expected BTC under another registry, got %s", m.string())
+	}
+	if _, ok := m.Add(New(1, 0, "PTS", "MAJOR")); ok {
+		t.Fatalf("expected adding BTC to PTS to fail")
+	}
+	if _, ok := parseCurrency("BTC"); ok {
+		t.Fatalf("expected BTC to be unknown to the registry in use")
+	}
+}
+
+func TestRegistriesWithTheSameCode(t *testing.T) {
+	t.Parallel()
+	r1, r2 := NewRegistry(), NewRegistry()
+	if err := r1.Register(CurrencyDefinition{Code: "GEM", Exponent: 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r2.Register(CurrencyDefinition{Code: "GEM", Exponent: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	whole := r1.New(1, 0, "GEM", "MAJOR")
+	cents := r2.New(1, 0, "GEM", "MAJOR")
+
+	if whole.Exponent() != 0 || cents.Exponent() != 2 || whole.EqualCurrency(cents) {
+		t.Fatalf("expected two different GEM currencies, got exponents %d and %d", whole.Exponent(), cents.Exponent())
+	}
+}
+
+func TestZeroRegistry(t *testing.T) {
+	t.Parallel()
+	var r Registry
+	if err := r.Register(btc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := r.lookup("BTC"); !ok {
+		t.Fatalf("expected BTC to be known in the registry")
+	}
+	if _, ok := r.lookup("EUR"); !ok {
+		t.Fatalf("expected EUR to be known in the registry")
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	defer UseRegistry(NewRegistry())()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			code := fmt.Sprintf("PT%d", i)
+			if err := RegisterCurrency(CurrencyDefinition{Code: code, Exponent: 2}); err != nil {
+				t.Error(err)
+			}
+			for j := 0; j < 100; j++ {
+				if m := New(1, 0, code, "MAJOR"); m.Currency() != code {
+					t.Errorf("expected %s, got %q", code, m.Currency())
+				}
+				New(1, 0, "EUR", "MAJOR").Add(NewEuro(1, 0))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,148 @@
+package money
+
+import (
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+)
+
+// how a value is brought to a fixed number of decimal places
+// the zero value is RoundHalfUp, which is what a Converter or tax.Calculator rounds with unless told otherwise
+type RoundingMode int
+
+const (
+	// ties are rounded away from zero, e.g. 1.005 -> 1.01, -1.005 -> -1.01
+	RoundHalfUp RoundingMode = iota
+	// ties are rounded to the even neighbour, i.e. banker's rounding, e.g. 1.005 -> 1.00, 1.015 -> 1.02
+	RoundHalfEven
+	// ties are rounded towards zero, e.g. 1.005 -> 1.00, -1.005 -> -1.00
+	RoundHalfDown
+	// away from zero, e.g. 1.001 -> 1.01, -1.001 -> -1.01
+	RoundUp
+	// towards zero, i.e. truncation, e.g. 1.009 -> 1.00, -1.009 -> -1.00
+	RoundDown
+	// towards positive infinity, e.g. 1.001 -> 1.01, -1.009 -> -1.00
+	RoundCeiling
+	// towards negative infinity, e.g. 1.009 -> 1.00, -1.001 -> -1.01
+	RoundFloor
+	// not a rounding, the value is left at full precision
+	// kept last so that new modes are added above it
+	RoundNone
+)
+
+func (r RoundingMode) round(d decimal.Decimal, places int32) decimal.Decimal {
+	switch r {
+	case RoundHalfUp:
+		return d.Round(places)
+	case RoundHalfEven:
+		return d.RoundBank(places)
+	case RoundHalfDown:
+		rounded := d.Round(places)
+		if d.Sub(rounded).Abs().Equal(decimal.New(5, -places-1)) {
+			return d.RoundDown(places)
+		}
+		return rounded
+	case RoundUp:
+		return d.RoundUp(places)
+	case RoundDown:
+		return d.RoundDown(places)
+	case RoundCeiling:
+		return d.RoundCeil(places)
+	case RoundFloor:
+		return d.RoundFloor(places)
+	default:
+		return d
+	}
+}
+
+// rounds to the minor unit of the currency, e.g. 2 decimal places for EUR euro, 0 for EUR cent
+func (m Money) Round(mode RoundingMode) Money {
+	ci, ok := m.currency.info()
+	if !ok {
+		return m
+	}
+
+	places := ci.exponent
+	if m.unit == MINOR {
+		places = 0
+	}
+
+	return m.RoundTo(places, mode)
+}
+
+// rounds to the given number of decimal places of the money's unit
+func (m Money) RoundTo(places int32, mode RoundingMode) Money {
+	if m.small && places >= m.scale() {
+		return m
+	}
+
+	return m.withDec(mode.round(m.dec(), places))
+}
+
+// returns the smallest amount of the currency settled in cash, in the money's unit, e.g. 0.05 CHF franc or 5 CHF rappen
+// this is the minor unit for currencies without a cash rounding rule
+func (m Money) CashIncrement() Money {
+	ci, ok := m.currency.info()
+	if !ok {
+		return defaultMoney()
+	}
+
+	cash := ci.cash
+	if cash == 0 {
+		cash = 1
+	}
+
+	exp := -ci.exponent
+	if m.unit == MINOR {
+		exp = 0
+	}
+
+	return Money{
+		value:    decimal.New(cash, exp),
+		currency: m.currency,
+		unit:     m.unit,
+	}.packed()
+}
+
+// rounds half up to the currency's cash increment, e.g. 1.02 CHF -> 1.00 CHF and 1.03 CHF -> 1.05 CHF
+// returns the amount payable in cash and the difference payable - m, for booking to a rounding account
+func (m Money) RoundCash() (payable Money, difference Money) {
+	return m.RoundCashWith(RoundHalfUp)
+}
+
+// rounds to the currency's cash increment with the given mode, e.g. RoundDown for payouts
+func (m Money) RoundCashWith(mode RoundingMode) (payable Money, difference Money) {
+	inc := m.CashIncrement()
+	if !inc.valid() {
+		return defaultMoney(), defaultMoney()
+	}
+
+	v := m.dec()
+	payable = m.withDec(mode.round(v.Div(inc.dec()), 0).Mul(inc.dec()))
+	difference = m.withDec(payable.dec().Sub(v))
+
+	return payable, difference
+}
+
+var autoRounding = int32(RoundNone)
+
+// sets the rounding applied to the results of Add, Subtract, Multiply, Divide and MultiplyFloat
+// and their error returning forms, so that they always fit the currency's minor unit
+// defaults to RoundNone, which leaves results at full precision
+func SetAutoRounding(mode RoundingMode) {
+	atomic.StoreInt32(&autoRounding, int32(mode))
+}
+
+// returns the rounding set with SetAutoRounding
+func AutoRounding() RoundingMode {
+	return RoundingMode(atomic.LoadInt32(&autoRounding))
+}
+
+func (m Money) autoRound() Money {
+	mode := AutoRounding()
+	if mode == RoundNone {
+		return m
+	}
+
+	return m.Round(mode)
+}
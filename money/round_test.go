@@ -0,0 +1,118 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundingModes(t *testing.T) {
+	cases := []struct {
+		mode RoundingMode
+		in   string
+		out  string
+	}{
+		{RoundHalfUp, "1.005", "1.01"},
+		{RoundHalfUp, "-1.005", "-1.01"},
+		{RoundHalfUp, "1.004", "1"},
+		{RoundHalfEven, "1.005", "1"},
+		{RoundHalfEven, "1.015", "1.02"},
+		{RoundHalfEven, "-1.005", "-1"},
+		{RoundHalfEven, "1.0051", "1.01"},
+		{RoundHalfDown, "1.005", "1"},
+		{RoundHalfDown, "-1.005", "-1"},
+		{RoundHalfDown, "1.0051", "1.01"},
+		{RoundUp, "1.001", "1.01"},
+		{RoundUp, "-1.001", "-1.01"},
+		{RoundDown, "1.009", "1"},
+		{RoundDown, "-1.009", "-1"},
+		{RoundCeiling, "1.001", "1.01"},
+		{RoundCeiling, "-1.009", "-1"},
+		{RoundFloor, "1.009", "1"},
+		{RoundFloor, "-1.001", "-1.01"},
+		{RoundNone, "1.009", "1.009"},
+	}
+
+	for _, c := range cases {
+		m := NewEuroFromDecimal(decimal.RequireFromString(c.in))
+		e := NewEuroFromDecimal(decimal.RequireFromString(c.out))
+
+		r := m.Round(c.mode)
+
+		if !r.Equal(e) {
+			t.Fatalf("mode %d: expected %s to round to %s but got %s", c.mode, c.in, c.out, r.dec())
+		}
+	}
+}
+
+func TestRoundMinorUnit(t *testing.T) {
+	m := NewEuroCent(12345, -1)
+
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(1234, 0), m.Round(RoundHalfEven).RoundTo(0, RoundNone))
+
+	jpy := New(12345, -1, "JPY", "yen")
+
+	moneyTest{t}.assertMoneyEqual(New(1235, 0, "JPY", "yen"), jpy.Round(RoundHalfUp))
+
+	kwd := New(12345, -4, "KWD", "major")
+
+	moneyTest{t}.assertMoneyEqual(New(1235, -3, "KWD", "major"), kwd.Round(RoundHalfUp))
+}
+
+func TestAutoRounding(t *testing.T) {
+	SetAutoRounding(RoundHalfEven)
+	defer SetAutoRounding(RoundNone)
+
+	r, ok := NewEuro(100, 0).Divide(NewEuro(3, 0))
+	if !ok {
+		t.Fatalf("expected same currency to divide")
+	}
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(3333, -2), r)
+
+	moneyTest{t}.assertMoneyEqual(NewEuro(599, -2), NewEuro(12482, -2).MultiplyFloat(0.048))
+
+	if AutoRounding() != RoundHalfEven {
+		t.Fatalf("expected RoundHalfEven but got %d", AutoRounding())
+	}
+}
+
+func TestRoundCash(t *testing.T) {
+	cases := []struct {
+		in         Money
+		payable    Money
+		difference Money
+	}{
+		{New(102, -2, "CHF", "MAJOR"), New(100, -2, "CHF", "MAJOR"), New(-2, -2, "CHF", "MAJOR")},
+		{New(103, -2, "CHF", "MAJOR"), New(105, -2, "CHF", "MAJOR"), New(2, -2, "CHF", "MAJOR")},
+		{New(1025, -3, "CHF", "MAJOR"), New(105, -2, "CHF", "MAJOR"), New(25, -3, "CHF", "MAJOR")},
+		{New(-103, -2, "CHF", "MAJOR"), New(-105, -2, "CHF", "MAJOR"), New(-2, -2, "CHF", "MAJOR")},
+		{New(103, 0, "CHF", "RAPPEN"), New(105, 0, "CHF", "RAPPEN"), New(2, 0, "CHF", "RAPPEN")},
+		{New(1249, -2, "SEK", "MAJOR"), New(12, 0, "SEK", "MAJOR"), New(-49, -2, "SEK", "MAJOR")},
+		{New(1225, -2, "DKK", "MAJOR"), New(1250, -2, "DKK", "MAJOR"), New(25, -2, "DKK", "MAJOR")},
+		{New(1234, -2, "NZD", "MAJOR"), New(1230, -2, "NZD", "MAJOR"), New(-4, -2, "NZD", "MAJOR")},
+		{New(1234, -2, "EUR", "MAJOR"), New(1234, -2, "EUR", "MAJOR"), New(0, 0, "EUR", "MAJOR")},
+		{New(1234, -3, "EUR", "MAJOR"), New(123, -2, "EUR", "MAJOR"), New(-4, -3, "EUR", "MAJOR")},
+	}
+
+	for _, c := range cases {
+		payable, difference := c.in.RoundCash()
+		moneyTest{t}.assertMoneyEqual(c.payable, payable)
+		moneyTest{t}.assertMoneyEqual(c.difference, difference)
+	}
+
+	payable, difference := New(104, -2, "CHF", "MAJOR").RoundCashWith(RoundDown)
+	moneyTest{t}.assertMoneyEqual(New(100, -2, "CHF", "MAJOR"), payable)
+	moneyTest{t}.assertMoneyEqual(New(-4, -2, "CHF", "MAJOR"), difference)
+
+	if payable, _ := defaultMoney().RoundCash(); payable.valid() {
+		t.Fatalf("expected invalid money")
+	}
+}
+
+func TestCashIncrement(t *testing.T) {
+	moneyTest{t}.assertMoneyEqual(New(5, -2, "CHF", "MAJOR"), New(1, 0, "CHF", "MAJOR").CashIncrement())
+	moneyTest{t}.assertMoneyEqual(New(5, 0, "CHF", "MINOR"), New(1, 0, "CHF", "MINOR").CashIncrement())
+	moneyTest{t}.assertMoneyEqual(New(1, 0, "JPY", "MAJOR"), New(1, 0, "JPY", "MAJOR").CashIncrement())
+	moneyTest{t}.assertMoneyEqual(NewEuro(1, -2), ZeroEuro().CashIncrement())
+}
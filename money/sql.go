@@ -0,0 +1,212 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// how money is laid out in a database column
+type SQLEncoding int
+
+const (
+	// a single text column holding the major unit value and the code, e.g. "12.34 EUR"
+	SQLText SQLEncoding = iota
+	// an integer column of minor units, the currency being fixed for the column, e.g. 1234 for 12.34 EUR
+	SQLMinorUnits
+	// a composite (amount, currency) value as used by postgres row types, e.g. "(12.34,EUR)"
+	SQLComposite
+)
+
+// implements driver.Valuer, storing the money as SQLText
+func (m Money) Value() (driver.Value, error) {
+	return SQLValue{Money: &m}.Value()
+}
+
+// implements sql.Scanner, reading SQLText or SQLComposite
+// NULL is an error, use NullMoney for nullable columns
+func (m *Money) Scan(src interface{}) error {
+	return SQLValue{Money: m}.Scan(src)
+}
+
+// binds money to a column with a specific encoding, for use as a query argument or scan destination, e.g.
+// row.Scan(money.SQLValue{Money: &m, Encoding: money.SQLMinorUnits, Currency: "EUR"})
+// a nil Money is written as NULL, while scanning needs a Money, use NullMoney to read nullable columns
+type SQLValue struct {
+	Money    *Money
+	Encoding SQLEncoding
+	// currency of an SQLMinorUnits column
+	Currency string
+}
+
+func (v SQLValue) Value() (driver.Value, error) {
+	if v.Money == nil {
+		return nil, nil
+	}
+
+	m := *v.Money
+	if !m.valid() {
+		return nil, fmt.Errorf("money: sql value %s: %w", m.string(), ErrInvalidMoney)
+	}
+
+	switch v.Encoding {
+	case SQLMinorUnits:
+		c, ok := parseCurrency(v.Currency)
+		if !ok {
+			return nil, fmt.Errorf("money: sql value for currency %q: %w", v.Currency, ErrUnknownCurrency)
+		}
+		if c != m.currency {
+			return nil, fmt.Errorf("money: sql value %s for a %s column: %w", m.string(), c.string(), ErrCurrencyMismatch)
+		}
+
+		minor := m.ToMinor().dec()
+		if !minor.IsInteger() {
+			return nil, fmt.Errorf("money: sql value %s is not a whole number of minor units", m.string())
+		}
+		if !minor.BigInt().IsInt64() {
+			return nil, fmt.Errorf("money: sql value %s does not fit an integer column", m.string())
+		}
+
+		return minor.IntPart(), nil
+	case SQLComposite:
+		return fmt.Sprintf("(%s,%s)", m.ToMajor().dec().String(), m.currency.string()), nil
+	default:
+		return fmt.Sprintf("%s %s", m.ToMajor().dec().String(), m.currency.string()), nil
+	}
+}
+
+func (v SQLValue) Scan(src interface{}) error {
+	if src == nil {
+		return fmt.Errorf("money: cannot scan NULL into Money, use NullMoney")
+	}
+	if v.Money == nil {
+		return fmt.Errorf("money: cannot scan into a nil Money")
+	}
+
+	var m Money
+	var err error
+	if v.Encoding == SQLMinorUnits {
+		m, err = scanMinorUnits(src, v.Currency)
+	} else {
+		m, err = scanText(src)
+	}
+	if err != nil {
+		return err
+	}
+
+	*v.Money = m
+	return nil
+}
+
+func scanMinorUnits(src interface{}, code string) (Money, error) {
+	c, ok := parseCurrency(code)
+	if !ok {
+		return defaultMoney(), fmt.Errorf("money: scan into currency %q: %w", code, ErrUnknownCurrency)
+	}
+
+	var units int64
+	switch s := src.(type) {
+	case int64:
+		units = s
+	case []byte, string:
+		var err error
+		units, err = strconv.ParseInt(fmt.Sprintf("%s", s), 10, 64)
+		if err != nil {
+			return defaultMoney(), fmt.Errorf("money: scan minor units: %w", err)
+		}
+	default:
+		return defaultMoney(), fmt.Errorf("money: cannot scan %T into minor units", src)
+	}
+
+	// a currency without a minor unit counts in its major unit
+	u := MINOR
+	if ci, _ := c.info(); ci.exponent == 0 {
+		u = MAJOR
+	}
+
+	return Money{
+		value:    decimal.New(units, 0),
+		currency: c,
+		unit:     u,
+	}.packed(), nil
+}
+
+// reads either "12.34 EUR" or "(12.34,EUR)"
+func scanText(src interface{}) (Money, error) {
+	var s string
+	switch t := src.(type) {
+	case string:
+		s = t
+	case []byte:
+		s = string(t)
+	default:
+		return defaultMoney(), fmt.Errorf("money: cannot scan %T into Money", src)
+	}
+
+	var fields []string
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "(") && strings.HasSuffix(trimmed, ")") {
+		fields = strings.Split(trimmed[1:len(trimmed)-1], ",")
+		for i, f := range fields {
+			fields[i] = strings.Trim(strings.TrimSpace(f), `"`)
+		}
+	} else {
+		fields = strings.Fields(trimmed)
+	}
+
+	if len(fields) != 2 {
+		return defaultMoney(), fmt.Errorf("money: cannot scan %q into Money", s)
+	}
+
+	v, err := decimal.NewFromString(fields[0])
+	if err != nil {
+		return defaultMoney(), fmt.Errorf("money: scan %q: %w", s, err)
+	}
+
+	c, ok := parseCurrency(fields[1])
+	if !ok {
+		return defaultMoney(), fmt.Errorf("money: scan %q: %w", s, ErrUnknownCurrency)
+	}
+
+	return Money{
+		value:    v,
+		currency: c,
+		unit:     MAJOR,
+	}.packed(), nil
+}
+
+// money for nullable columns, in the manner of sql.NullString
+// Encoding and Currency lay the column out as for SQLValue, the zero value being an SQLText column
+type NullMoney struct {
+	Money    Money
+	Valid    bool
+	Encoding SQLEncoding
+	// currency of an SQLMinorUnits column
+	Currency string
+}
+
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return SQLValue{Money: &n.Money, Encoding: n.Encoding, Currency: n.Currency}.Value()
+}
+
+// Valid is false after NULL or an error
+func (n *NullMoney) Scan(src interface{}) error {
+	n.Money, n.Valid = Money{}, false
+	if src == nil {
+		return nil
+	}
+
+	if err := (SQLValue{Money: &n.Money, Encoding: n.Encoding, Currency: n.Currency}).Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package money
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = Money{}
+	_ sql.Scanner   = &Money{}
+	_ driver.Valuer = SQLValue{}
+	_ sql.Scanner   = SQLValue{}
+	_ driver.Valuer = NullMoney{}
+	_ sql.Scanner   = &NullMoney{}
+)
+
+func TestSQLTextValue(t *testing.T) {
+	v, err := NewEuroCent(1234, 0).Value()
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+
+	if v != "12.34 EUR" {
+		t.Fatalf(`expected "12.34 EUR" but got %v`, v)
+	}
+
+	if _, err := defaultMoney().Value(); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney but got %v", err)
+	}
+
+	if v, err := (SQLValue{}).Value(); err != nil || v != nil {
+		t.Fatalf("expected a nil Money to be NULL but got %v, %v", v, err)
+	}
+
+	if err := (SQLValue{}).Scan("12.34 EUR"); err == nil {
+		t.Fatalf("expected an error scanning into a nil Money")
+	}
+}
+
+func TestSQLTextScan(t *testing.T) {
+	for _, src := range []interface{}{"12.34 EUR", []byte("12.34 EUR"), "(12.34,EUR)", `( 12.34 , "EUR" )`} {
+		var m Money
+		if err := m.Scan(src); err != nil {
+			t.Fatalf("did not expect an error scanning %v, got %v", src, err)
+		}
+
+		moneyTest{t}.assertMoneyEqual(NewEuro(1234, -2), m)
+	}
+
+	for _, src := range []interface{}{nil, "12.34", "12.34 XYZ", "abc EUR", int64(1234)} {
+		var m Money
+		if err := m.Scan(src); err == nil {
+			t.Fatalf("expected an error scanning %v", src)
+		}
+	}
+}
+
+func TestSQLMinorUnits(t *testing.T) {
+	v, err := SQLValue{Money: &Money{}, Encoding: SQLMinorUnits, Currency: "EUR"}.Value()
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if v != int64(0) {
+		t.Fatalf("expected 0 but got %v", v)
+	}
+
+	m := NewEuro(1234, -2)
+	v, err = SQLValue{Money: &m, Encoding: SQLMinorUnits, Currency: "EUR"}.Value()
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if v != int64(1234) {
+		t.Fatalf("expected 1234 but got %v", v)
+	}
+
+	var r Money
+	if err := (SQLValue{Money: &r, Encoding: SQLMinorUnits, Currency: "EUR"}).Scan(int64(1234)); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(1234, 0), r)
+
+	if err := (SQLValue{Money: &r, Encoding: SQLMinorUnits, Currency: "JPY"}).Scan(int64(500)); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	moneyTest{t}.assertMoneyEqual(New(500, 0, "JPY", "yen"), r)
+
+	if _, err := (SQLValue{Money: &m, Encoding: SQLMinorUnits, Currency: "USD"}).Value(); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch but got %v", err)
+	}
+
+	fraction := NewEuro(12345, -3)
+	if _, err := (SQLValue{Money: &fraction, Encoding: SQLMinorUnits, Currency: "EUR"}).Value(); err == nil {
+		t.Fatalf("expected an error storing sub minor unit precision")
+	}
+}
+
+func TestSQLComposite(t *testing.T) {
+	m := New(1234, 0, "JPY", "yen")
+
+	v, err := SQLValue{Money: &m, Encoding: SQLComposite}.Value()
+	if err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if v != "(1234,JPY)" {
+		t.Fatalf(`expected "(1234,JPY)" but got %v`, v)
+	}
+
+	var r Money
+	if err := (SQLValue{Money: &r, Encoding: SQLComposite}).Scan(v); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	moneyTest{t}.assertMoneyEqual(m, r)
+}
+
+func TestNullMoney(t *testing.T) {
+	var n NullMoney
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if n.Valid {
+		t.Fatalf("expected NULL to scan as invalid")
+	}
+
+	v, err := n.Value()
+	if err != nil || v != nil {
+		t.Fatalf("expected a nil value but got %v, %v", v, err)
+	}
+
+	if err := n.Scan("5 USD"); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if !n.Valid {
+		t.Fatalf("expected a valid NullMoney")
+	}
+	moneyTest{t}.assertMoneyEqual(New(5, 0, "USD", "dollar"), n.Money)
+
+	v, err = n.Value()
+	if err != nil || v != "5 USD" {
+		t.Fatalf(`expected "5 USD" but got %v, %v`, v, err)
+	}
+}
+
+func TestNullMoneyMinorUnits(t *testing.T) {
+	n := NullMoney{Encoding: SQLMinorUnits, Currency: "EUR"}
+	if err := n.Scan(int64(1234)); err != nil {
+		t.Fatalf("did not expect an error, got %v", err)
+	}
+	if !n.Valid {
+		t.Fatalf("expected a valid NullMoney")
+	}
+	moneyTest{t}.assertMoneyEqual(NewEuroCent(1234, 0), n.Money)
+
+	v, err := n.Value()
+	if err != nil || v != int64(1234) {
+		t.Fatalf("expected 1234 but got %v, %v", v, err)
+	}
+
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("expected NULL to scan as invalid, got %v, %v", n.Valid, err)
+	}
+
+	n.Valid = true
+	if err := n.Scan("not money"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if n.Valid {
+		t.Fatalf("expected an error to leave the NullMoney invalid")
+	}
+}
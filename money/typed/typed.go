@@ -0,0 +1,229 @@
+// Package typed gives money a currency known at compile time, so that adding euros to dollars
+// does not build, e.g.
+//
+//	price := typed.New[typed.EUR](1999, -2)
+//	total := price.Add(typed.New[typed.EUR](500, -2))
+//
+// an Amount converts to and from money.Money, and works with the generic money.Sum, money.Min and money.Max
+package typed
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+// a currency as a type, Code being its ISO 4217 code
+// other currencies are declared the same way, e.g.
+//
+//	type SEK struct{}
+//
+//	func (SEK) Code() string { return "SEK" }
+type Currency interface {
+	Code() string
+}
+
+type (
+	EUR struct{}
+	USD struct{}
+	GBP struct{}
+	JPY struct{}
+	CHF struct{}
+	CAD struct{}
+	AUD struct{}
+	CNY struct{}
+)
+
+func (EUR) Code() string { return "EUR" }
+func (USD) Code() string { return "USD" }
+func (GBP) Code() string { return "GBP" }
+func (JPY) Code() string { return "JPY" }
+func (CHF) Code() string { return "CHF" }
+func (CAD) Code() string { return "CAD" }
+func (AUD) Code() string { return "AUD" }
+func (CNY) Code() string { return "CNY" }
+
+// money in the currency C
+// the zero value is zero in the major unit of C
+type Amount[C Currency] struct {
+	m  money.Money
+	ok bool
+}
+
+func code[C Currency]() string {
+	var c C
+	return c.Code()
+}
+
+// creates val * 10^exp in the major unit of C, e.g. New[EUR](1999, -2) for 19.99 EUR
+func New[C Currency](val int64, exp int32) Amount[C] {
+	return Amount[C]{m: money.New(val, exp, code[C](), "MAJOR"), ok: true}
+}
+
+// creates d in the major unit of C
+func NewFromDecimal[C Currency](d decimal.Decimal) Amount[C] {
+	return Amount[C]{m: money.NewFromDecimal(d, code[C](), "MAJOR"), ok: true}
+}
+
+// checks m is valid money in C, keeping its unit
+func FromMoney[C Currency](m money.Money) (Amount[C], error) {
+	if m.Currency() == "" {
+		return Amount[C]{}, fmt.Errorf("typed: from money: %w", money.ErrInvalidMoney)
+	}
+	if m.Currency() != code[C]() {
+		return Amount[C]{}, fmt.Errorf("typed: %s money as %s: %w", m.Currency(), code[C](), money.ErrCurrencyMismatch)
+	}
+
+	return Amount[C]{m: m, ok: true}, nil
+}
+
+// returns the amount as dynamically typed money
+func (a Amount[C]) Money() money.Money {
+	if !a.ok {
+		return money.New(0, 0, code[C](), "MAJOR")
+	}
+
+	return a.m
+}
+
+func (a Amount[C]) Currency() string {
+	return code[C]()
+}
+
+func (a Amount[C]) ValueDecimal() decimal.Decimal {
+	return a.Money().ValueDecimal()
+}
+
+// returns a + b, in the unit of a
+// panics when C is not a currency known to the money package, AddErr returns the error instead
+func (a Amount[C]) Add(b Amount[C]) Amount[C] {
+	return must(a.AddErr(b))
+}
+
+// returns a - b, in the unit of a
+// panics when C is not a currency known to the money package, SubtractErr returns the error instead
+func (a Amount[C]) Subtract(b Amount[C]) Amount[C] {
+	return must(a.SubtractErr(b))
+}
+
+func must[C Currency](a Amount[C], err error) Amount[C] {
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// returns a + b, the error being nil for any currency known to the money package
+// it lets Amount meet the constraint of money.Sum
+func (a Amount[C]) AddErr(b Amount[C]) (Amount[C], error) {
+	r, err := a.Money().AddErr(b.Money())
+	if err != nil {
+		return Amount[C]{}, err
+	}
+
+	return Amount[C]{m: r, ok: true}, nil
+}
+
+// returns a - b, the error as for AddErr
+func (a Amount[C]) SubtractErr(b Amount[C]) (Amount[C], error) {
+	r, err := a.Money().SubtractErr(b.Money())
+	if err != nil {
+		return Amount[C]{}, err
+	}
+
+	return Amount[C]{m: r, ok: true}, nil
+}
+
+// returns -1, 0 or 1 as a is less than, equal to or greater than b, the error as for AddErr
+// it lets Amount meet the constraint of money.Min and money.Max
+func (a Amount[C]) Cmp(b Amount[C]) (int, error) {
+	return a.Money().Cmp(b.Money())
+}
+
+func (a Amount[C]) Equal(b Amount[C]) bool {
+	return a.Money().Equal(b.Money())
+}
+
+func (a Amount[C]) IsZero() bool {
+	return a.Money().IsZero()
+}
+
+func (a Amount[C]) Sign() int {
+	return a.Money().Sign()
+}
+
+func (a Amount[C]) Neg() Amount[C] {
+	return Amount[C]{m: a.Money().Neg(), ok: true}
+}
+
+func (a Amount[C]) Abs() Amount[C] {
+	return Amount[C]{m: a.Money().Abs(), ok: true}
+}
+
+// rounds to the minor unit of C, see money.Money.Round
+func (a Amount[C]) Round(mode money.RoundingMode) Amount[C] {
+	return Amount[C]{m: a.Money().Round(mode), ok: true}
+}
+
+// returns p of a, see money.Money.ApplyPercent
+func (a Amount[C]) ApplyPercent(p money.Percent) Amount[C] {
+	return Amount[C]{m: a.Money().ApplyPercent(p), ok: true}
+}
+
+// splits a loss free, see money.Money.Allocate
+func (a Amount[C]) Allocate(ratios ...int) ([]Amount[C], error) {
+	ms, err := a.Money().Allocate(ratios...)
+	if err != nil {
+		return nil, err
+	}
+
+	as := make([]Amount[C], len(ms))
+	for i, m := range ms {
+		as[i] = Amount[C]{m: m, ok: true}
+	}
+
+	return as, nil
+}
+
+// printed and logged as money.Money would be
+func (a Amount[C]) String() string {
+	return a.Money().String()
+}
+
+func (a Amount[C]) Format(f fmt.State, verb rune) {
+	a.Money().Format(f, verb)
+}
+
+func (a Amount[C]) LogValue() slog.Value {
+	return a.Money().LogValue()
+}
+
+// written as money.Money would be
+func (a Amount[C]) MarshalJSON() ([]byte, error) {
+	return a.Money().MarshalJSON()
+}
+
+// reads money.Money, which must be in C
+// null leaves the amount unchanged
+func (a *Amount[C]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	var m money.Money
+	if err := m.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	r, err := FromMoney[C](m)
+	if err != nil {
+		return err
+	}
+	*a = r
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package typed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jacobklenner/go-utils/money"
+)
+
+type SEK struct{}
+
+func (SEK) Code() string { return "SEK" }
+
+// not a currency the money package knows
+type ZZZ struct{}
+
+func (ZZZ) Code() string { return "ZZZ" }
+
+func assertAmount[C Currency](t *testing.T, e Amount[C], r Amount[C]) {
+	t.Helper()
+	if !e.Equal(r) {
+		t.Fatalf("expected %s %s, got %s %s", e.ValueDecimal(), e.Currency(), r.ValueDecimal(), r.Currency())
+	}
+}
+
+func TestAmount(t *testing.T) {
+	price := New[EUR](1999, -2)
+	cents, err := FromMoney[EUR](money.NewEuroCent(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertAmount(t, New[EUR](20, 0), price.Add(cents))
+	assertAmount(t, New[EUR](1998, -2), price.Subtract(cents))
+	assertAmount(t, New[EUR](-1999, -2), price.Neg())
+	assertAmount(t, price, price.Neg().Abs())
+	assertAmount(t, New[EUR](2, 0), price.ApplyPercent(mustPercent("10%")).Round(money.RoundHalfUp))
+
+	if price.Currency() != "EUR" || price.Sign() != 1 || price.IsZero() {
+		t.Fatalf("unexpected %s %s", price.ValueDecimal(), price.Currency())
+	}
+	if m := price.Money(); !m.Equal(money.NewEuro(1999, -2)) {
+		t.Fatalf("unexpected money %s", m.ValueDecimal())
+	}
+
+	// a currency declared outside the package
+	assertAmount(t, New[SEK](3, 0), New[SEK](1, 0).Add(New[SEK](2, 0)))
+}
+
+func mustPercent(s string) money.Percent {
+	p, err := money.ParsePercent(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func TestZeroAmount(t *testing.T) {
+	var a Amount[USD]
+	if !a.IsZero() || a.Money().Currency() != "USD" {
+		t.Fatalf("expected zero USD, got %s %s", a.ValueDecimal(), a.Money().Currency())
+	}
+	assertAmount(t, New[USD](5, 0), a.Add(New[USD](5, 0)))
+}
+
+func TestFromMoney(t *testing.T) {
+	if _, err := FromMoney[EUR](money.ZeroUsDollar()); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := FromMoney[EUR](money.New(1, 0, "ZZZ", "MAJOR")); !errors.Is(err, money.ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+}
+
+func TestGenericHelpers(t *testing.T) {
+	as := []Amount[EUR]{New[EUR](1, 0), New[EUR](250, -2), New[EUR](-5, -1)}
+
+	s, err := money.Sum(as)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](3, 0), s)
+
+	max, err := money.Max(as...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](250, -2), max)
+
+	min, err := money.Min(as...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](-5, -1), min)
+
+	if _, err := money.Sum[Amount[EUR]](nil); !errors.Is(err, money.ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	// the dynamic form through the same helpers
+	m, err := money.Sum([]money.Money{as[0].Money(), as[1].Money()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Equal(money.NewEuro(35, -1)) {
+		t.Fatalf("unexpected sum %s", m.ValueDecimal())
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	parts, err := New[EUR](100, -2).Allocate(1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](34, -2), parts[0])
+	assertAmount(t, New[EUR](33, -2), parts[2])
+}
+
+func TestAmountJSON(t *testing.T) {
+	var v struct {
+		Price Amount[EUR] `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":{"value":"12.5","currency":"EUR","unit":"EURO"}}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](125, -1), v.Price)
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"price":{"value":"12.5","currency":"EUR","unit":"EURO"}}` {
+		t.Fatalf("unexpected json %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":{"value":"12.5","currency":"USD","unit":"DOLLAR"}}`), &v); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, New[EUR](125, -1), v.Price)
+
+	var usd Amount[USD]
+	if err := json.Unmarshal([]byte(`null`), &usd); err != nil || !usd.IsZero() {
+		t.Fatalf("expected null to leave zero USD, got %s, %v", usd, err)
+	}
+}
+
+func TestUnknownCurrency(t *testing.T) {
+	if _, err := New[ZZZ](1, 0).AddErr(New[ZZZ](1, 0)); !errors.Is(err, money.ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+	if _, err := New[ZZZ](1, 0).SubtractErr(New[ZZZ](1, 0)); !errors.Is(err, money.ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+
+	for _, f := range []func(){
+		func() { New[ZZZ](1, 0).Add(New[ZZZ](1, 0)) },
+		func() { New[ZZZ](1, 0).Subtract(New[ZZZ](1, 0)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for an unknown currency")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	var zero Amount[JPY]
+	a := New[EUR](1234, -2)
+
+	if r := fmt.Sprintf("%v %d %+v %s", a, a, a, zero); r != "12.34 EUR 1234 12.34 EUR (EURO) 0 JPY" {
+		t.Fatalf("unexpected %q", r)
+	}
+}
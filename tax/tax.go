@@ -0,0 +1,331 @@
+package tax
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrUnknownCategory = errors.New("unknown tax category")
+	ErrNoItems         = errors.New("no items")
+)
+
+// a tax charged at a fixed rate, e.g. VAT at 19%
+type Rate struct {
+	Name    string
+	Percent money.Percent
+	// a compound tax is charged on the net amount plus the taxes listed before it, e.g. Quebec QST on top of GST
+	Compound bool
+}
+
+// the rates charged on each category of goods, in the order they are applied
+// e.g. "standard" -> VAT 19%, "reduced" -> VAT 7%, a category with no rates is exempt
+type Table map[string][]Rate
+
+// when tax is rounded to the minor unit of the currency
+type Rounding int
+
+const (
+	// the tax of every line is rounded, the invoice totals summing the rounded lines
+	PerLine Rounding = iota
+	// the tax for each rate is rounded once on the invoice, and spread over the lines by largest remainder
+	PerInvoice
+)
+
+// an invoice line, Amount being net or gross as set by the Calculator
+type Item struct {
+	Description string
+	Category    string
+	Amount      money.Money
+}
+
+// works out the tax on invoices from a rate table
+type Calculator struct {
+	Rates Table
+	// whether item amounts include tax, i.e. are gross rather than net
+	Inclusive bool
+	Rounding  Rounding
+	Mode      money.RoundingMode
+}
+
+// the tax on a single item, Net + Tax == Gross
+type Line struct {
+	Item  Item
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+	// the tax for each rate of the item's category, in the order of the table
+	Taxes []money.Money
+}
+
+// the tax charged at one rate across an invoice
+type RateTotal struct {
+	Rate Rate
+	// the amount the rate was charged on
+	Base money.Money
+	Tax  money.Money
+}
+
+// the tax on an invoice, the rate totals summing to Tax and the lines to Net, Tax and Gross
+type Breakdown struct {
+	Lines []Line
+	// ordered by first use
+	Rates []RateTotal
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+}
+
+// returns the gross amount and the tax on net at the given rates
+func NetToGross(net money.Money, rates ...Rate) (Line, error) {
+	return single(net, false, rates)
+}
+
+// returns the net amount and the tax included in gross at the given rates
+func GrossToNet(gross money.Money, rates ...Rate) (Line, error) {
+	return single(gross, true, rates)
+}
+
+func single(m money.Money, inclusive bool, rates []Rate) (Line, error) {
+	c := Calculator{Rates: Table{"": rates}, Inclusive: inclusive}
+
+	b, err := c.Calculate([]Item{{Amount: m}})
+	if err != nil {
+		return Line{}, err
+	}
+
+	return b.Lines[0], nil
+}
+
+// the unrounded tax of an item
+type exact struct {
+	net     decimal.Decimal
+	taxes   []decimal.Decimal
+	rounded []decimal.Decimal
+}
+
+// works out the tax on items, which must all share a currency
+func (c Calculator) Calculate(items []Item) (Breakdown, error) {
+	if len(items) == 0 {
+		return Breakdown{}, fmt.Errorf("tax: calculate: %w", ErrNoItems)
+	}
+
+	code := items[0].Amount.Currency()
+	exponent := items[0].Amount.Exponent()
+
+	es := make([]exact, len(items))
+	for i, item := range items {
+		if item.Amount.Currency() == "" {
+			return Breakdown{}, fmt.Errorf("tax: item %d: %w", i, money.ErrInvalidMoney)
+		}
+		if !item.Amount.EqualCurrency(items[0].Amount) {
+			return Breakdown{}, fmt.Errorf("tax: item %d in %s on a %s invoice: %w", i, item.Amount.Currency(), code, money.ErrCurrencyMismatch)
+		}
+
+		rates, ok := c.Rates[item.Category]
+		if !ok {
+			return Breakdown{}, fmt.Errorf("tax: item %d category %q: %w", i, item.Category, ErrUnknownCategory)
+		}
+
+		amount := item.Amount.ToMajor().ValueDecimal()
+		fs := factors(rates)
+
+		net := amount
+		if c.Inclusive {
+			total := decimal.NewFromInt(1)
+			for _, f := range fs {
+				total = total.Add(f)
+			}
+			net = amount.Div(total)
+		}
+
+		e := exact{net: net, taxes: make([]decimal.Decimal, len(fs))}
+		for j, f := range fs {
+			e.taxes[j] = net.Mul(f)
+		}
+		es[i] = e
+	}
+
+	round := func(d decimal.Decimal) decimal.Decimal {
+		return money.NewFromDecimal(d, code, "MAJOR").Round(c.Mode).ValueDecimal()
+	}
+
+	for i := range es {
+		es[i].rounded = make([]decimal.Decimal, len(es[i].taxes))
+		for j, t := range es[i].taxes {
+			es[i].rounded[j] = round(t)
+		}
+	}
+
+	if c.Rounding == PerInvoice {
+		unit := decimal.New(1, -exponent)
+		for _, shares := range c.byRate(items) {
+			spread(es, shares, round, unit)
+		}
+	}
+
+	return c.breakdown(items, es, code)
+}
+
+// returns the tax on a net amount of 1 for each rate
+func factors(rates []Rate) []decimal.Decimal {
+	fs := make([]decimal.Decimal, len(rates))
+	charged := decimal.Zero
+	for i, r := range rates {
+		base := decimal.NewFromInt(1)
+		if r.Compound {
+			base = base.Add(charged)
+		}
+		fs[i] = base.Mul(r.Percent.Fraction())
+		charged = charged.Add(fs[i])
+	}
+
+	return fs
+}
+
+// the position of a rate within the taxes of an item
+type share struct {
+	item int
+	tax  int
+}
+
+type rateKey struct {
+	name     string
+	value    string
+	compound bool
+}
+
+func key(r Rate) rateKey {
+	return rateKey{name: r.Name, value: r.Percent.String(), compound: r.Compound}
+}
+
+// groups the taxes of the items by rate, in order of first use
+func (c Calculator) byRate(items []Item) [][]share {
+	var groups [][]share
+	index := make(map[rateKey]int)
+
+	for i, item := range items {
+		for j, r := range c.Rates[item.Category] {
+			k := key(r)
+			g, ok := index[k]
+			if !ok {
+				g = len(groups)
+				index[k] = g
+				groups = append(groups, nil)
+			}
+			groups[g] = append(groups[g], share{item: i, tax: j})
+		}
+	}
+
+	return groups
+}
+
+// rounds the total of the shares once, moving whole minor units between the rounded shares so they sum to it
+// a unit goes to the share furthest below its exact value, or comes from the one furthest above it
+func spread(es []exact, shares []share, round func(decimal.Decimal) decimal.Decimal, unit decimal.Decimal) {
+	total := decimal.Zero
+	sum := decimal.Zero
+	for _, s := range shares {
+		total = total.Add(es[s.item].taxes[s.tax])
+		sum = sum.Add(es[s.item].rounded[s.tax])
+	}
+
+	diff := round(total).Sub(sum)
+	for !diff.IsZero() {
+		step := unit
+		if diff.IsNegative() {
+			step = unit.Neg()
+		}
+
+		best := -1
+		var bestGap decimal.Decimal
+		for i, s := range shares {
+			gap := es[s.item].taxes[s.tax].Sub(es[s.item].rounded[s.tax])
+			if step.IsNegative() {
+				gap = gap.Neg()
+			}
+			if best < 0 || gap.GreaterThan(bestGap) {
+				best, bestGap = i, gap
+			}
+		}
+
+		s := shares[best]
+		es[s.item].rounded[s.tax] = es[s.item].rounded[s.tax].Add(step)
+		diff = diff.Sub(step)
+	}
+}
+
+func (c Calculator) breakdown(items []Item, es []exact, code string) (Breakdown, error) {
+	zero := money.NewFromDecimal(decimal.Zero, code, "MAJOR")
+	b := Breakdown{Net: zero, Tax: zero, Gross: zero}
+	index := make(map[rateKey]int)
+
+	for i, item := range items {
+		amount := item.Amount.ToMajor()
+		line := Line{Item: item, Tax: zero, Taxes: make([]money.Money, len(es[i].rounded))}
+
+		var err error
+		for j, t := range es[i].rounded {
+			line.Taxes[j] = money.NewFromDecimal(t, code, "MAJOR")
+			if line.Tax, err = line.Tax.AddErr(line.Taxes[j]); err != nil {
+				return Breakdown{}, err
+			}
+		}
+
+		if c.Inclusive {
+			line.Gross = amount
+			line.Net, err = amount.SubtractErr(line.Tax)
+		} else {
+			line.Net = amount
+			line.Gross, err = amount.AddErr(line.Tax)
+		}
+		if err != nil {
+			return Breakdown{}, err
+		}
+
+		charged := zero
+		for j, r := range c.Rates[item.Category] {
+			base := line.Net
+			if r.Compound {
+				if base, err = base.AddErr(charged); err != nil {
+					return Breakdown{}, err
+				}
+			}
+			if charged, err = charged.AddErr(line.Taxes[j]); err != nil {
+				return Breakdown{}, err
+			}
+
+			k := key(r)
+			g, ok := index[k]
+			if !ok {
+				g = len(b.Rates)
+				index[k] = g
+				b.Rates = append(b.Rates, RateTotal{Rate: r, Base: zero, Tax: zero})
+			}
+
+			total := &b.Rates[g]
+			if total.Base, err = total.Base.AddErr(base); err != nil {
+				return Breakdown{}, err
+			}
+			if total.Tax, err = total.Tax.AddErr(line.Taxes[j]); err != nil {
+				return Breakdown{}, err
+			}
+		}
+
+		if b.Net, err = b.Net.AddErr(line.Net); err != nil {
+			return Breakdown{}, err
+		}
+		if b.Tax, err = b.Tax.AddErr(line.Tax); err != nil {
+			return Breakdown{}, err
+		}
+		if b.Gross, err = b.Gross.AddErr(line.Gross); err != nil {
+			return Breakdown{}, err
+		}
+		b.Lines = append(b.Lines, line)
+	}
+
+	return b, nil
+}
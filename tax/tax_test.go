@@ -0,0 +1,221 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jacobklenner/go-utils/money"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	vat19 = Rate{Name: "VAT", Percent: money.NewPercentFromBasisPoints(1900)}
+	vat7  = Rate{Name: "VAT reduced", Percent: money.NewPercentFromBasisPoints(700)}
+	gst   = Rate{Name: "GST", Percent: money.NewPercentFromBasisPoints(500)}
+	qst   = Rate{Name: "QST", Percent: money.NewPercent(decimal.RequireFromString("9.975")), Compound: true}
+)
+
+// checks the lines and rates of a breakdown add up to its totals
+func assertReconciles(t *testing.T, b Breakdown) {
+	t.Helper()
+	net, tax, gross, rates := money.NewEuro(0, 0), money.NewEuro(0, 0), money.NewEuro(0, 0), money.NewEuro(0, 0)
+	for i, l := range b.Lines {
+		if sum, _ := l.Net.AddErr(l.Tax); !sum.Equal(l.Gross) {
+			t.Fatalf("line %d: %s net and %s tax do not add up to %s gross", i, l.Net, l.Tax, l.Gross)
+		}
+		net, _ = net.AddErr(l.Net)
+		tax, _ = tax.AddErr(l.Tax)
+		gross, _ = gross.AddErr(l.Gross)
+	}
+	for _, r := range b.Rates {
+		rates, _ = rates.AddErr(r.Tax)
+	}
+
+	if !net.Equal(b.Net) || !tax.Equal(b.Tax) || !gross.Equal(b.Gross) || !rates.Equal(b.Tax) {
+		t.Fatalf("expected totals of %s net, %s tax by line, %s tax by rate and %s gross, got %s, %s and %s",
+			net, tax, rates, gross, b.Net, b.Tax, b.Gross)
+	}
+}
+
+func TestNetToGross(t *testing.T) {
+	cases := []struct {
+		net   money.Money
+		rates []Rate
+		tax   money.Money
+		gross money.Money
+	}{
+		{money.NewEuro(100, 0), []Rate{vat19}, money.NewEuro(19, 0), money.NewEuro(119, 0)},
+		{money.NewEuro(999, -2), []Rate{vat19}, money.NewEuro(190, -2), money.NewEuro(1189, -2)},
+		{money.NewEuro(100, 0), nil, money.NewEuro(0, 0), money.NewEuro(100, 0)},
+		{money.NewEuro(100, 0), []Rate{gst, qst}, money.NewEuro(1547, -2), money.NewEuro(11547, -2)},
+		{money.NewEuro(-10, 0), []Rate{vat19}, money.NewEuro(-190, -2), money.NewEuro(-1190, -2)},
+	}
+
+	for _, c := range cases {
+		l, err := NetToGross(c.net, c.rates...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !l.Tax.Equal(c.tax) || !l.Gross.Equal(c.gross) {
+			t.Fatalf("%s net: expected %s tax and %s gross, got %s and %s", c.net, c.tax, c.gross, l.Tax, l.Gross)
+		}
+	}
+}
+
+func TestGrossToNet(t *testing.T) {
+	cases := []struct {
+		gross money.Money
+		rates []Rate
+		tax   money.Money
+		net   money.Money
+	}{
+		{money.NewEuro(119, 0), []Rate{vat19}, money.NewEuro(19, 0), money.NewEuro(100, 0)},
+		{money.NewEuro(10, 0), []Rate{vat19}, money.NewEuro(160, -2), money.NewEuro(840, -2)},
+		{money.NewEuro(11547, -2), []Rate{gst, qst}, money.NewEuro(1547, -2), money.NewEuro(100, 0)},
+	}
+
+	for _, c := range cases {
+		l, err := GrossToNet(c.gross, c.rates...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !l.Tax.Equal(c.tax) || !l.Net.Equal(c.net) {
+			t.Fatalf("%s gross: expected %s tax and %s net, got %s and %s", c.gross, c.tax, c.net, l.Tax, l.Net)
+		}
+	}
+}
+
+func TestCompoundTaxes(t *testing.T) {
+	l, err := NetToGross(money.NewEuro(100, 0), gst, qst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Taxes[0].Equal(money.NewEuro(5, 0)) || !l.Taxes[1].Equal(money.NewEuro(1047, -2)) {
+		t.Fatalf("expected 5.00 EUR GST and 10.47 EUR QST, got %s and %s", l.Taxes[0], l.Taxes[1])
+	}
+}
+
+func TestRounding(t *testing.T) {
+	items := []Item{
+		{Category: "standard", Amount: money.NewEuro(12, -2)},
+		{Category: "standard", Amount: money.NewEuro(12, -2)},
+		{Category: "standard", Amount: money.NewEuro(12, -2)},
+	}
+	table := Table{"standard": {vat19}}
+
+	b, err := Calculator{Rates: table}.Calculate(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Tax.Equal(money.NewEuro(6, -2)) {
+		t.Fatalf("expected 0.06 EUR tax rounded per line, got %s", b.Tax)
+	}
+	assertReconciles(t, b)
+
+	b, err = Calculator{Rates: table, Rounding: PerInvoice}.Calculate(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Tax.Equal(money.NewEuro(7, -2)) || !b.Gross.Equal(money.NewEuro(43, -2)) {
+		t.Fatalf("expected 0.07 EUR tax and 0.43 EUR gross rounded per invoice, got %s and %s", b.Tax, b.Gross)
+	}
+	if !b.Lines[0].Tax.Equal(money.NewEuro(3, -2)) || !b.Lines[1].Tax.Equal(money.NewEuro(2, -2)) {
+		t.Fatalf("expected the invoice tax spread as 0.03 EUR and 0.02 EUR, got %s and %s", b.Lines[0].Tax, b.Lines[1].Tax)
+	}
+	assertReconciles(t, b)
+
+	b, err = Calculator{Rates: table, Rounding: PerInvoice, Mode: money.RoundDown}.Calculate(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Tax.Equal(money.NewEuro(6, -2)) {
+		t.Fatalf("expected 0.06 EUR tax rounded down, got %s", b.Tax)
+	}
+	assertReconciles(t, b)
+}
+
+func TestBreakdown(t *testing.T) {
+	table := Table{
+		"standard": {vat19},
+		"reduced":  {vat7},
+		"exempt":   nil,
+	}
+	items := []Item{
+		{Description: "laptop", Category: "standard", Amount: money.NewEuro(99999, -2)},
+		{Description: "book", Category: "reduced", Amount: money.NewEuro(1995, -2)},
+		{Description: "insurance", Category: "exempt", Amount: money.NewEuro(49, 0)},
+		{Description: "mouse", Category: "standard", Amount: money.NewEuro(2495, -2)},
+	}
+
+	for _, inclusive := range []bool{false, true} {
+		for _, r := range []Rounding{PerLine, PerInvoice} {
+			b, err := Calculator{Rates: table, Inclusive: inclusive, Rounding: r}.Calculate(items)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertReconciles(t, b)
+			if len(b.Rates) != 2 || b.Rates[0].Rate.Name != "VAT" || b.Rates[1].Rate.Name != "VAT reduced" {
+				t.Fatalf("unexpected rates %v", b.Rates)
+			}
+		}
+	}
+
+	b, err := Calculator{Rates: table}.Calculate(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exclusive := []struct {
+		e money.Money
+		r money.Money
+	}{
+		{money.NewEuro(102494, -2), b.Rates[0].Base},
+		{money.NewEuro(19474, -2), b.Rates[0].Tax},
+		{money.NewEuro(1995, -2), b.Rates[1].Base},
+		{money.NewEuro(140, -2), b.Rates[1].Tax},
+		{money.NewEuro(109389, -2), b.Net},
+		{money.NewEuro(129003, -2), b.Gross},
+	}
+	for i, c := range exclusive {
+		if !c.r.Equal(c.e) {
+			t.Fatalf("exclusive %d: expected %s, got %s", i, c.e, c.r)
+		}
+	}
+
+	b, err = Calculator{Rates: table, Inclusive: true}.Calculate(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inclusive := []struct {
+		e money.Money
+		r money.Money
+	}{
+		{money.NewEuro(109389, -2), b.Gross},
+		{money.NewEuro(16364, -2), b.Rates[0].Tax},
+		{money.NewEuro(131, -2), b.Rates[1].Tax},
+	}
+	for i, c := range inclusive {
+		if !c.r.Equal(c.e) {
+			t.Fatalf("inclusive %d: expected %s, got %s", i, c.e, c.r)
+		}
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	c := Calculator{Rates: Table{"standard": {vat19}}}
+
+	cases := []struct {
+		items []Item
+		err   error
+	}{
+		{nil, ErrNoItems},
+		{[]Item{{Category: "luxury", Amount: money.NewEuro(1, 0)}}, ErrUnknownCategory},
+		{[]Item{{Category: "standard", Amount: money.NewEuro(1, 0)}, {Category: "standard", Amount: money.New(1, 0, "USD", "MAJOR")}}, money.ErrCurrencyMismatch},
+		{[]Item{{Category: "standard", Amount: money.New(1, 0, "ZZZ", "MAJOR")}}, money.ErrInvalidMoney},
+	}
+
+	for _, cs := range cases {
+		if _, err := c.Calculate(cs.items); !errors.Is(err, cs.err) {
+			t.Fatalf("expected %v, got %v", cs.err, err)
+		}
+	}
+}